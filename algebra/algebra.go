@@ -3,6 +3,8 @@ package algebra
 import (
 	"errors"
 	"math"
+
+	"github.com/javiercbk/minesweeper/topology"
 )
 
 // OperationType is a minesweeper algebra operation type
@@ -17,6 +19,12 @@ const (
 	OpMark
 	// OpCompose is the compose operation type
 	OpCompose
+	// OpChord is the chord operation type
+	OpChord
+	// OpHint is the hint operation type
+	OpHint
+	// OpUndo is the undo operation type
+	OpUndo
 )
 
 var (
@@ -24,20 +32,38 @@ var (
 	ErrUnknownOperation = errors.New("unknown operation")
 	// ErrOperationOutOfBounds is returned when an operand is out of bounds or unknown
 	ErrOperationOutOfBounds = errors.New("reveal operation out of bounds")
+	// ErrIrreversibleOperation is returned when attempting to revert an operation that can not be reverted
+	ErrIrreversibleOperation = errors.New("the operation can not be reverted")
 )
 
 // MineProximity is the mine proximity value of a point in space
 type MineProximity = int
 
+const (
+	// MaxMineProximity is the greatest amount of mines next to a point, a point on a board with several layers
+	// has 26 siblings
+	MaxMineProximity = 26
+	// RevealedMine is the mine proximity of a revealed mine
+	RevealedMine = MaxMineProximity + 1
+	// UnrevealedMine is the mine proximity of an unrevealed mine
+	UnrevealedMine = -RevealedMine - 1
+	// MarkOffset is subtracted from the mine proximity of an unrevealed point every time it is marked
+	MarkOffset = RevealedMine + 1
+	// SuspectMine is the mine proximity of an unrevealed mine marked as a possible mine
+	SuspectMine = UnrevealedMine - MarkOffset
+	// MarkedMine is the mine proximity of an unrevealed mine marked as a certain mine
+	MarkedMine = SuspectMine - MarkOffset
+)
+
 // operationExecution is the behaviour of all the operations of the minesweep algebra
 type operationExecution func(mineProximity MineProximity) (MineProximity, error)
 
 // reveal is the operation that reveals a point in the board.
 func reveal(mineProximity MineProximity) (MineProximity, error) {
-	if mineProximity >= 0 && mineProximity <= 8 {
+	if mineProximity >= 0 && mineProximity <= MaxMineProximity {
 		return mineProximity, nil
 	}
-	if mineProximity >= -10 && mineProximity <= -1 {
+	if mineProximity >= UnrevealedMine && mineProximity <= -1 {
 		return MineProximity(math.Abs(float64(mineProximity)) - 1), nil
 	}
 	return 0, ErrOperationOutOfBounds
@@ -45,23 +71,64 @@ func reveal(mineProximity MineProximity) (MineProximity, error) {
 
 // mark is the operation that marks a point in the board as a possible or certain mine.
 func mark(mineProximity MineProximity) (MineProximity, error) {
-	if mineProximity >= 0 && mineProximity <= 8 {
+	if mineProximity >= 0 && mineProximity <= MaxMineProximity {
 		return mineProximity, nil
 	}
-	if mineProximity >= -10 && mineProximity <= -1 {
-		return MineProximity(mineProximity - 10), nil
+	if mineProximity >= UnrevealedMine && mineProximity <= -1 {
+		return MineProximity(mineProximity - MarkOffset), nil
+	}
+	if mineProximity >= SuspectMine && mineProximity <= -1-MarkOffset {
+		return MineProximity(mineProximity - MarkOffset), nil
 	}
-	if mineProximity >= -20 && mineProximity <= -11 {
-		return MineProximity(mineProximity - 10), nil
+	if mineProximity >= MarkedMine && mineProximity <= -1-2*MarkOffset {
+		return MineProximity(mineProximity + 2*MarkOffset), nil
 	}
-	if mineProximity >= -30 && mineProximity <= -21 {
-		return MineProximity(mineProximity + 20), nil
+	return 0, ErrOperationOutOfBounds
+}
+
+// chord is the operation that reveals the unmarked siblings of a revealed point, leaving the point untouched.
+func chord(mineProximity MineProximity) (MineProximity, error) {
+	if mineProximity >= 0 && mineProximity <= MaxMineProximity {
+		return mineProximity, nil
 	}
 	return 0, ErrOperationOutOfBounds
 }
 
+// hint is the operation that records that a point was suggested to the player, it never changes the point.
+func hint(mineProximity MineProximity) (MineProximity, error) {
+	return mineProximity, nil
+}
+
+// undo is the operation that takes back the last operation on a point. The point is restored to the value
+// computed by Revert, so the execution never changes the point.
+func undo(mineProximity MineProximity) (MineProximity, error) {
+	return mineProximity, nil
+}
+
+// Revert returns the mine proximity a point had before an operation of the given type left it with the given
+// mine proximity
+func Revert(opType OperationType, mineProximity MineProximity) (MineProximity, error) {
+	if opType == OpReveal {
+		if mineProximity >= 0 && mineProximity <= RevealedMine {
+			return MineProximity(-mineProximity - 1), nil
+		}
+		return 0, ErrOperationOutOfBounds
+	}
+	if opType == OpMark {
+		if mineProximity >= UnrevealedMine && mineProximity <= -1 {
+			return MineProximity(mineProximity - 2*MarkOffset), nil
+		}
+		if mineProximity >= MarkedMine && mineProximity <= -1-MarkOffset {
+			return MineProximity(mineProximity + MarkOffset), nil
+		}
+		return 0, ErrOperationOutOfBounds
+	}
+	return 0, ErrIrreversibleOperation
+}
+
 // Operation is the behaviour of all the operations of the minesweep algebra
 type Operation struct {
+	layer  int
 	x      int
 	y      int
 	opType OperationType
@@ -73,10 +140,16 @@ func (o Operation) Exec(mineProximity MineProximity) (MineProximity, error) {
 	return o.exec(mineProximity)
 }
 
-// NewOperation creates an Operation object
+// NewOperation creates an Operation object on a board with a single layer
 func NewOperation(opType OperationType, x, y int) (Operation, error) {
+	return NewLayeredOperation(opType, 0, x, y)
+}
+
+// NewLayeredOperation creates an Operation object on a layer of a board
+func NewLayeredOperation(opType OperationType, layer, x, y int) (Operation, error) {
 	var err error
 	oper := Operation{
+		layer:  layer,
 		x:      x,
 		y:      y,
 		opType: opType,
@@ -85,12 +158,27 @@ func NewOperation(opType OperationType, x, y int) (Operation, error) {
 		oper.exec = reveal
 	} else if opType == OpMark {
 		oper.exec = mark
+	} else if opType == OpChord {
+		oper.exec = chord
+	} else if opType == OpHint {
+		oper.exec = hint
+	} else if opType == OpUndo {
+		oper.exec = undo
 	} else {
 		err = ErrUnknownOperation
 	}
 	return oper, err
 }
 
+// Board is the board where the operations are applied, it decides which points are next to each other
+type Board struct {
+	Layers int
+	// Rows is the amount of rows of every layer
+	Rows       int
+	Cols       int
+	Neighbours topology.Neighbours
+}
+
 // CompositionResult is the result of a Compose operation
 type CompositionResult struct {
 	Apply  []Operation
@@ -98,16 +186,25 @@ type CompositionResult struct {
 	Delta2 Operation
 }
 
-// Compose composes two operations
-func Compose(oper1, oper2 Operation) CompositionResult {
+// Compose composes two operations on a board
+func Compose(board Board, oper1, oper2 Operation) CompositionResult {
 	result := CompositionResult{}
-	if oper1.x == oper2.x && oper1.y == oper2.y {
+	if oper1.opType == OpHint || oper2.opType == OpHint {
+		// a hint does not change the board, apply both
+		result.Apply = []Operation{oper1, oper2}
+		result.Delta1 = oper2
+		result.Delta2 = oper1
+	} else if oper1.layer == oper2.layer && oper1.x == oper2.x && oper1.y == oper2.y {
 		// the operation is on the same point, apply the first operation
 		result.Apply = []Operation{oper1}
 		if oper1.opType != oper2.opType {
 			// if is a different, then apply the operation 1 in the delta 2
 			result.Delta2 = oper1
 		}
+	} else if (oper1.opType == OpMark || oper1.opType == OpUndo) && oper2.opType == OpChord && board.areSiblings(oper1, oper2) {
+		// the mark or the undo changes the siblings the chord relies on, apply only the first operation
+		result.Apply = []Operation{oper1}
+		result.Delta2 = oper1
 	} else {
 		// reveal both points
 		result.Apply = []Operation{oper1, oper2}
@@ -117,10 +214,24 @@ func Compose(oper1, oper2 Operation) CompositionResult {
 	return result
 }
 
-// ShouldOperationApply decides whether an operation should be applied
-func ShouldOperationApply(operations []Operation, oper Operation) bool {
+// areSiblings returns true if the points of both operations are next to each other on the board
+func (b Board) areSiblings(oper1, oper2 Operation) bool {
+	// the neighbours of a board with several layers stack the rows of every layer
+	row1 := oper1.layer*b.Rows + oper1.x
+	row2 := oper2.layer*b.Rows + oper2.x
+	var buf [26]topology.Point
+	for _, p := range b.Neighbours(b.Layers*b.Rows, b.Cols, row1, oper1.y, buf[:0]) {
+		if p.Row == row2 && p.Col == oper2.y {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldOperationApply decides whether an operation should be applied after the given operations on a board
+func ShouldOperationApply(board Board, operations []Operation, oper Operation) bool {
 	for _, o := range operations {
-		cr := Compose(o, oper)
+		cr := Compose(board, o, oper)
 		if cr.Delta1.opType == OpUnknown {
 			// operation has been un applied
 			return false
@@ -3,6 +3,8 @@ package algebra
 import (
 	"fmt"
 	"testing"
+
+	"github.com/javiercbk/minesweeper/topology"
 )
 
 type algebraTest struct {
@@ -70,7 +72,7 @@ func TestReveal(t *testing.T) {
 		},
 		{
 			oper:      reveal,
-			proximity: MineProximity(9),
+			proximity: MineProximity(27),
 			expected:  MineProximity(0),
 			err:       ErrOperationOutOfBounds,
 		},
@@ -130,16 +132,29 @@ func TestReveal(t *testing.T) {
 		},
 		{
 			oper:      reveal,
-			proximity: MineProximity(-10),
-			expected:  MineProximity(9),
+			proximity: MineProximity(-28),
+			expected:  MineProximity(27),
 			err:       nil,
 		},
 		{
 			oper:      reveal,
-			proximity: MineProximity(-11),
+			proximity: MineProximity(-29),
 			expected:  MineProximity(0),
 			err:       ErrOperationOutOfBounds,
 		},
+		{
+			// a point on a board with several layers can have up to 26 mines around
+			oper:      reveal,
+			proximity: MineProximity(26),
+			expected:  MineProximity(26),
+			err:       nil,
+		},
+		{
+			oper:      reveal,
+			proximity: MineProximity(-27),
+			expected:  MineProximity(26),
+			err:       nil,
+		},
 	}
 	for i, test := range testTable {
 		proximity, err := test.oper(test.proximity)
@@ -210,202 +225,276 @@ func TestMark(t *testing.T) {
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(9),
+			proximity: MineProximity(27),
 			expected:  MineProximity(0),
 			err:       ErrOperationOutOfBounds,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(10),
+			proximity: MineProximity(28),
 			expected:  MineProximity(0),
 			err:       ErrOperationOutOfBounds,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-1),
-			expected:  MineProximity(-11),
+			expected:  MineProximity(-29),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-2),
-			expected:  MineProximity(-12),
+			expected:  MineProximity(-30),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-3),
-			expected:  MineProximity(-13),
+			expected:  MineProximity(-31),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-4),
-			expected:  MineProximity(-14),
+			expected:  MineProximity(-32),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-5),
-			expected:  MineProximity(-15),
+			expected:  MineProximity(-33),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-6),
-			expected:  MineProximity(-16),
+			expected:  MineProximity(-34),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-7),
-			expected:  MineProximity(-17),
+			expected:  MineProximity(-35),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-8),
-			expected:  MineProximity(-18),
+			expected:  MineProximity(-36),
 			err:       nil,
 		},
 		{
 			oper:      mark,
 			proximity: MineProximity(-9),
-			expected:  MineProximity(-19),
+			expected:  MineProximity(-37),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-10),
-			expected:  MineProximity(-20),
+			proximity: MineProximity(-28),
+			expected:  MineProximity(-56),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-11),
-			expected:  MineProximity(-21),
+			proximity: MineProximity(-29),
+			expected:  MineProximity(-57),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-12),
-			expected:  MineProximity(-22),
+			proximity: MineProximity(-30),
+			expected:  MineProximity(-58),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-13),
-			expected:  MineProximity(-23),
+			proximity: MineProximity(-31),
+			expected:  MineProximity(-59),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-14),
-			expected:  MineProximity(-24),
+			proximity: MineProximity(-32),
+			expected:  MineProximity(-60),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-15),
-			expected:  MineProximity(-25),
+			proximity: MineProximity(-33),
+			expected:  MineProximity(-61),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-16),
-			expected:  MineProximity(-26),
+			proximity: MineProximity(-34),
+			expected:  MineProximity(-62),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-17),
-			expected:  MineProximity(-27),
+			proximity: MineProximity(-35),
+			expected:  MineProximity(-63),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-18),
-			expected:  MineProximity(-28),
+			proximity: MineProximity(-36),
+			expected:  MineProximity(-64),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-19),
-			expected:  MineProximity(-29),
+			proximity: MineProximity(-37),
+			expected:  MineProximity(-65),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-20),
-			expected:  MineProximity(-30),
+			proximity: MineProximity(-56),
+			expected:  MineProximity(-84),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-21),
+			proximity: MineProximity(-57),
 			expected:  MineProximity(-1),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-22),
+			proximity: MineProximity(-58),
 			expected:  MineProximity(-2),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-23),
+			proximity: MineProximity(-59),
 			expected:  MineProximity(-3),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-24),
+			proximity: MineProximity(-60),
 			expected:  MineProximity(-4),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-25),
+			proximity: MineProximity(-61),
 			expected:  MineProximity(-5),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-26),
+			proximity: MineProximity(-62),
 			expected:  MineProximity(-6),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-27),
+			proximity: MineProximity(-63),
 			expected:  MineProximity(-7),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-28),
+			proximity: MineProximity(-64),
 			expected:  MineProximity(-8),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-29),
+			proximity: MineProximity(-65),
 			expected:  MineProximity(-9),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-30),
-			expected:  MineProximity(-10),
+			proximity: MineProximity(-84),
+			expected:  MineProximity(-28),
 			err:       nil,
 		},
 		{
 			oper:      mark,
-			proximity: MineProximity(-31),
+			proximity: MineProximity(-85),
 			expected:  MineProximity(0),
 			err:       ErrOperationOutOfBounds,
 		},
+		{
+			oper:      mark,
+			proximity: MineProximity(26),
+			expected:  MineProximity(26),
+			err:       nil,
+		},
+		{
+			oper:      mark,
+			proximity: MineProximity(-27),
+			expected:  MineProximity(-55),
+			err:       nil,
+		},
+		{
+			oper:      mark,
+			proximity: MineProximity(-83),
+			expected:  MineProximity(-27),
+			err:       nil,
+		},
+	}
+	for i, test := range testTable {
+		proximity, err := test.oper(test.proximity)
+		if proximity != test.expected {
+			t.Fatalf("test %d failed: expected proximity %d but got %d", i, test.expected, proximity)
+		}
+		if err != test.err {
+			t.Fatalf("test %d failed: expected err %v but got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestChord(t *testing.T) {
+	testTable := []algebraTest{
+		{
+			oper:      chord,
+			proximity: MineProximity(0),
+			expected:  MineProximity(0),
+			err:       nil,
+		},
+		{
+			oper:      chord,
+			proximity: MineProximity(3),
+			expected:  MineProximity(3),
+			err:       nil,
+		},
+		{
+			oper:      chord,
+			proximity: MineProximity(8),
+			expected:  MineProximity(8),
+			err:       nil,
+		},
+		{
+			oper:      chord,
+			proximity: MineProximity(27),
+			expected:  MineProximity(0),
+			err:       ErrOperationOutOfBounds,
+		},
+		{
+			oper:      chord,
+			proximity: MineProximity(-1),
+			expected:  MineProximity(0),
+			err:       ErrOperationOutOfBounds,
+		},
+		{
+			oper:      chord,
+			proximity: MineProximity(-58),
+			expected:  MineProximity(0),
+			err:       ErrOperationOutOfBounds,
+		},
+		{
+			oper:      chord,
+			proximity: MineProximity(26),
+			expected:  MineProximity(26),
+			err:       nil,
+		},
 	}
 	for i, test := range testTable {
 		proximity, err := test.oper(test.proximity)
@@ -422,6 +511,9 @@ func compareOperation(expected, actual Operation) error {
 	if expected.opType != actual.opType {
 		return fmt.Errorf("expected operation type to be %d but was %d", expected.opType, actual.opType)
 	}
+	if expected.layer != actual.layer {
+		return fmt.Errorf("expected layer to be %d but was %d", expected.layer, actual.layer)
+	}
 	if expected.x != actual.x {
 		return fmt.Errorf("expected x to be %d but was %d", expected.x, actual.x)
 	}
@@ -431,11 +523,139 @@ func compareOperation(expected, actual Operation) error {
 	return nil
 }
 
+func TestHint(t *testing.T) {
+	testTable := []algebraTest{
+		{
+			oper:      hint,
+			proximity: MineProximity(3),
+			expected:  MineProximity(3),
+			err:       nil,
+		},
+		{
+			oper:      hint,
+			proximity: MineProximity(-28),
+			expected:  MineProximity(-28),
+			err:       nil,
+		},
+		{
+			oper:      hint,
+			proximity: MineProximity(-58),
+			expected:  MineProximity(-58),
+			err:       nil,
+		},
+	}
+	for i, test := range testTable {
+		proximity, err := test.oper(test.proximity)
+		if proximity != test.expected {
+			t.Fatalf("test %d failed: expected proximity %d but got %d", i, test.expected, proximity)
+		}
+		if err != test.err {
+			t.Fatalf("test %d failed: expected err %v but got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestRevert(t *testing.T) {
+	testTable := []struct {
+		opType    OperationType
+		proximity MineProximity
+		expected  MineProximity
+		err       error
+	}{
+		{
+			opType:    OpReveal,
+			proximity: MineProximity(0),
+			expected:  MineProximity(-1),
+		},
+		{
+			opType:    OpReveal,
+			proximity: MineProximity(27),
+			expected:  MineProximity(-28),
+		},
+		{
+			opType:    OpReveal,
+			proximity: MineProximity(-3),
+			err:       ErrOperationOutOfBounds,
+		},
+		{
+			opType:    OpMark,
+			proximity: MineProximity(-31),
+			expected:  MineProximity(-3),
+		},
+		{
+			opType:    OpMark,
+			proximity: MineProximity(-84),
+			expected:  MineProximity(-56),
+		},
+		{
+			opType:    OpMark,
+			proximity: MineProximity(-4),
+			expected:  MineProximity(-60),
+		},
+		{
+			opType:    OpMark,
+			proximity: MineProximity(2),
+			err:       ErrOperationOutOfBounds,
+		},
+		{
+			opType:    OpReveal,
+			proximity: MineProximity(26),
+			expected:  MineProximity(-27),
+		},
+		{
+			opType:    OpMark,
+			proximity: MineProximity(-83),
+			expected:  MineProximity(-55),
+		},
+		{
+			opType:    OpChord,
+			proximity: MineProximity(2),
+			err:       ErrIrreversibleOperation,
+		},
+		{
+			opType:    OpHint,
+			proximity: MineProximity(-2),
+			err:       ErrIrreversibleOperation,
+		},
+	}
+	for i, test := range testTable {
+		proximity, err := Revert(test.opType, test.proximity)
+		if err != test.err {
+			t.Fatalf("test %d failed: expected err %v but got %v", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if proximity != test.expected {
+			t.Fatalf("test %d failed: expected proximity %d but got %d", i, test.expected, proximity)
+		}
+		// reverting must undo the operation
+		var oper operationExecution = reveal
+		if test.opType == OpMark {
+			oper = mark
+		}
+		executed, err := oper(proximity)
+		if err != nil || executed != test.proximity {
+			t.Fatalf("test %d failed: expected the operation to restore %d but got %d, %v", i, test.proximity, executed, err)
+		}
+	}
+}
+
 func TestCompose(t *testing.T) {
 	reveal1, _ := NewOperation(OpReveal, 0, 0)
 	reveal2, _ := NewOperation(OpReveal, 0, 1)
 	mark1, _ := NewOperation(OpMark, 0, 0)
 	mark2, _ := NewOperation(OpMark, 0, 1)
+	mark3, _ := NewOperation(OpMark, 0, 2)
+	chord1, _ := NewOperation(OpChord, 0, 0)
+	chord2, _ := NewOperation(OpChord, 0, 1)
+	hint1, _ := NewOperation(OpHint, 0, 0)
+	undo1, _ := NewOperation(OpUndo, 0, 0)
+	undo2, _ := NewOperation(OpUndo, 0, 1)
+	layeredReveal1, _ := NewLayeredOperation(OpReveal, 1, 0, 0)
+	layeredMark2, _ := NewLayeredOperation(OpMark, 1, 0, 1)
+	layeredMark3, _ := NewLayeredOperation(OpMark, 2, 0, 0)
+	cube := Board{Layers: 3, Rows: 3, Cols: 3, Neighbours: topology.Cube(3)}
 	testTable := []struct {
 		oper1    Operation
 		oper2    Operation
@@ -498,9 +718,139 @@ func TestCompose(t *testing.T) {
 				Delta2: mark1,
 			},
 		},
+		{
+			oper1: chord1,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply: []Operation{chord1},
+			},
+		},
+		{
+			oper1: reveal1,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply:  []Operation{reveal1},
+				Delta2: reveal1,
+			},
+		},
+		{
+			oper1: mark2,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply:  []Operation{mark2},
+				Delta2: mark2,
+			},
+		},
+		{
+			oper1: mark3,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply:  []Operation{mark3, chord1},
+				Delta1: chord1,
+				Delta2: mark3,
+			},
+		},
+		{
+			oper1: chord1,
+			oper2: mark2,
+			expected: CompositionResult{
+				Apply:  []Operation{chord1, mark2},
+				Delta1: mark2,
+				Delta2: chord1,
+			},
+		},
+		{
+			oper1: reveal2,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply:  []Operation{reveal2, chord1},
+				Delta1: chord1,
+				Delta2: reveal2,
+			},
+		},
+		{
+			oper1: chord2,
+			oper2: reveal1,
+			expected: CompositionResult{
+				Apply:  []Operation{chord2, reveal1},
+				Delta1: reveal1,
+				Delta2: chord2,
+			},
+		},
+		{
+			oper1: hint1,
+			oper2: reveal1,
+			expected: CompositionResult{
+				Apply:  []Operation{hint1, reveal1},
+				Delta1: reveal1,
+				Delta2: hint1,
+			},
+		},
+		{
+			oper1: mark1,
+			oper2: hint1,
+			expected: CompositionResult{
+				Apply:  []Operation{mark1, hint1},
+				Delta1: hint1,
+				Delta2: mark1,
+			},
+		},
+		{
+			oper1: undo1,
+			oper2: reveal1,
+			expected: CompositionResult{
+				Apply:  []Operation{undo1},
+				Delta2: undo1,
+			},
+		},
+		{
+			oper1: undo2,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply:  []Operation{undo2},
+				Delta2: undo2,
+			},
+		},
+		{
+			oper1: undo2,
+			oper2: mark1,
+			expected: CompositionResult{
+				Apply:  []Operation{undo2, mark1},
+				Delta1: mark1,
+				Delta2: undo2,
+			},
+		},
+		{
+			// the same row and column on another layer is another point
+			oper1: layeredReveal1,
+			oper2: mark1,
+			expected: CompositionResult{
+				Apply:  []Operation{layeredReveal1, mark1},
+				Delta1: mark1,
+				Delta2: layeredReveal1,
+			},
+		},
+		{
+			// points on adjacent layers are siblings
+			oper1: layeredMark2,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply:  []Operation{layeredMark2},
+				Delta2: layeredMark2,
+			},
+		},
+		{
+			oper1: layeredMark3,
+			oper2: chord1,
+			expected: CompositionResult{
+				Apply:  []Operation{layeredMark3, chord1},
+				Delta1: chord1,
+				Delta2: layeredMark3,
+			},
+		},
 	}
 	for i, test := range testTable {
-		composition := Compose(test.oper1, test.oper2)
+		composition := Compose(cube, test.oper1, test.oper2)
 		if len(composition.Apply) != len(test.expected.Apply) {
 			t.Fatalf("test %d failed: expected apply to have len %d but got len %d", i, len(test.expected.Apply), len(composition.Apply))
 		}
@@ -508,6 +858,9 @@ func TestCompose(t *testing.T) {
 			if composition.Apply[j].opType != test.expected.Apply[j].opType {
 				t.Fatalf("test %d failed: expected apply operation index %d to be %d but was %d", i, j, test.expected.Apply[j].opType, composition.Apply[j].opType)
 			}
+			if composition.Apply[j].layer != test.expected.Apply[j].layer {
+				t.Fatalf("test %d failed: expected apply operation index %d to be on layer %d but was %d", i, j, test.expected.Apply[j].layer, composition.Apply[j].layer)
+			}
 			if composition.Apply[j].x != test.expected.Apply[j].x {
 				t.Fatalf("test %d failed: expected apply operation index %d to be %d but was %d", i, j, test.expected.Apply[j].x, composition.Apply[j].x)
 			}
@@ -525,3 +878,55 @@ func TestCompose(t *testing.T) {
 		}
 	}
 }
+
+func TestComposeTopology(t *testing.T) {
+	chord1, _ := NewOperation(OpChord, 0, 1)
+	chord2, _ := NewOperation(OpChord, 0, 0)
+	mark1, _ := NewOperation(OpMark, 1, 2)
+	mark2, _ := NewOperation(OpMark, 0, 4)
+	testTable := []struct {
+		board    Board
+		oper1    Operation
+		oper2    Operation
+		siblings bool
+	}{
+		{
+			board:    Board{Layers: 1, Rows: 4, Cols: 5, Neighbours: topology.Square},
+			oper1:    mark1,
+			oper2:    chord1,
+			siblings: true,
+		},
+		{
+			// the point below and to the right of an even row is not next to it in a hex board
+			board:    Board{Layers: 1, Rows: 4, Cols: 5, Neighbours: topology.Hex},
+			oper1:    mark1,
+			oper2:    chord1,
+			siblings: false,
+		},
+		{
+			board:    Board{Layers: 1, Rows: 4, Cols: 5, Neighbours: topology.Square},
+			oper1:    mark2,
+			oper2:    chord2,
+			siblings: false,
+		},
+		{
+			// the last column is next to the first one when the board wraps
+			board:    Board{Layers: 1, Rows: 4, Cols: 5, Neighbours: topology.SquareTorus},
+			oper1:    mark2,
+			oper2:    chord2,
+			siblings: true,
+		},
+	}
+	for i, test := range testTable {
+		composition := Compose(test.board, test.oper1, test.oper2)
+		if test.siblings && len(composition.Apply) != 1 {
+			t.Fatalf("test %d failed: expected only the mark to be applied but got %d operations", i, len(composition.Apply))
+		}
+		if !test.siblings && len(composition.Apply) != 2 {
+			t.Fatalf("test %d failed: expected both operations to be applied but got %d operations", i, len(composition.Apply))
+		}
+		if ShouldOperationApply(test.board, []Operation{test.oper1}, test.oper2) == test.siblings {
+			t.Fatalf("test %d failed: expected the chord to be applied to be %v", i, !test.siblings)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"sync"
+
+	"github.com/javiercbk/minesweeper/algebra"
+)
+
+// eventBufferSize is the amount of events that a follower of a game can fall behind before being dropped
+const eventBufferSize = 64
+
+// Event is a change of a game that is pushed to the clients that follow it. Operation is a committed operation
+// and Status is only set when the operation finished the game.
+type Event struct {
+	Operation *Operation `json:"operation,omitempty"`
+	Status    *Status    `json:"status,omitempty"`
+}
+
+// hub delivers the events of every game to its followers. It only knows the followers connected to this server.
+type hub struct {
+	mutex     sync.Mutex
+	followers map[int64]map[chan Event]struct{}
+}
+
+// events is the hub shared by every game API of the server
+var events = newHub()
+
+func newHub() *hub {
+	return &hub{
+		followers: make(map[int64]map[chan Event]struct{}),
+	}
+}
+
+// follow returns a channel with every event of a game published from now on. The channel is closed when the
+// follower unfollows the game or when it falls too far behind.
+func (h *hub) follow(gameID int64) chan Event {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	eventChan := make(chan Event, eventBufferSize)
+	gameFollowers, ok := h.followers[gameID]
+	if !ok {
+		gameFollowers = make(map[chan Event]struct{})
+		h.followers[gameID] = gameFollowers
+	}
+	gameFollowers[eventChan] = struct{}{}
+	return eventChan
+}
+
+// unfollow stops delivering the events of a game to a channel
+func (h *hub) unfollow(gameID int64, eventChan chan Event) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.drop(gameID, eventChan)
+}
+
+// publish delivers an event to every follower of a game without waiting for them
+func (h *hub) publish(gameID int64, e Event) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for eventChan := range h.followers[gameID] {
+		select {
+		case eventChan <- e:
+		default:
+			// a slow follower must not hold the game back, it has to catch up by retrieving the game again
+			h.drop(gameID, eventChan)
+		}
+	}
+}
+
+func (h *hub) drop(gameID int64, eventChan chan Event) {
+	gameFollowers, ok := h.followers[gameID]
+	if !ok {
+		return
+	}
+	if _, ok = gameFollowers[eventChan]; !ok {
+		return
+	}
+	delete(gameFollowers, eventChan)
+	close(eventChan)
+	if len(gameFollowers) == 0 {
+		delete(h.followers, gameID)
+	}
+}
+
+// confirmationEvent is the event of an applied operation
+func confirmationEvent(confirmation OperationConfirmation) Event {
+	operation := confirmation.Operation
+	e := Event{
+		Operation: &operation,
+	}
+	if confirmation.Status.Won || confirmation.Status.Lost || confirmation.Status.Resigned {
+		status := confirmation.Status
+		e.Status = &status
+	}
+	return e
+}
+
+// hintEvent is the event of a hint recorded as an operation of a game
+func hintEvent(gameID int64, hint Hint) Event {
+	return Event{
+		Operation: &Operation{
+			ID:      hint.OperationID,
+			GameID:  gameID,
+			Op:      algebra.OpHint,
+			Layer:   hint.Layer,
+			Row:     hint.Row,
+			Col:     hint.Col,
+			Applied: true,
+		},
+	}
+}
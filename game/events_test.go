@@ -0,0 +1,60 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestHubPublish(t *testing.T) {
+	h := newHub()
+	first := h.follow(1)
+	second := h.follow(1)
+	other := h.follow(2)
+	e := confirmationEvent(OperationConfirmation{
+		Operation: Operation{ID: 3, GameID: 1, Applied: true},
+		Status:    Status{Lost: true},
+	})
+	h.publish(1, e)
+	for i, eventChan := range []chan Event{first, second} {
+		select {
+		case received := <-eventChan:
+			if received.Operation.ID != 3 || received.Status == nil || !received.Status.Lost {
+				t.Fatalf("follower %d expected event %v but got %v\n", i, e, received)
+			}
+		default:
+			t.Fatalf("follower %d expected an event\n", i)
+		}
+	}
+	select {
+	case received := <-other:
+		t.Fatalf("expected no event for another game but got %v\n", received)
+	default:
+	}
+	h.unfollow(1, first)
+	if _, ok := <-first; ok {
+		t.Fatalf("expected the channel to be closed after unfollowing\n")
+	}
+	// unfollowing twice must not close the channel again
+	h.unfollow(1, first)
+	h.publish(1, hintEvent(1, Hint{OperationID: 4}))
+	if received := <-second; received.Operation.ID != 4 || received.Status != nil {
+		t.Fatalf("expected the hint event but got %v\n", received)
+	}
+}
+
+func TestHubDropSlowFollower(t *testing.T) {
+	h := newHub()
+	slow := h.follow(1)
+	for i := 0; i <= eventBufferSize; i++ {
+		h.publish(1, hintEvent(1, Hint{OperationID: i + 1}))
+	}
+	received := 0
+	for range slow {
+		received++
+	}
+	if received != eventBufferSize {
+		t.Fatalf("expected %d events before dropping the follower but got %d\n", eventBufferSize, received)
+	}
+	if len(h.followers) != 0 {
+		t.Fatalf("expected the game to have no followers but had %d\n", len(h.followers[1]))
+	}
+}
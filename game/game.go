@@ -1,12 +1,15 @@
 package game
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/javiercbk/minesweeper/gamefile"
 	"github.com/javiercbk/minesweeper/http/response"
 	"github.com/javiercbk/minesweeper/http/security"
 	"github.com/labstack/echo"
@@ -31,6 +34,30 @@ type sgResponse struct {
 	Game StatefulGame `json:"game"`
 }
 
+type hResponse struct {
+	Hint Hint `json:"hint"`
+}
+
+type stResponse struct {
+	Status Status `json:"status"`
+}
+
+type rpResponse struct {
+	Replay Replay `json:"replay"`
+}
+
+type psResponse struct {
+	Presets []Preset `json:"presets"`
+}
+
+type ptsResponse struct {
+	Participants []Participant `json:"participants"`
+}
+
+type ptResponse struct {
+	Participant Participant `json:"participant"`
+}
+
 // Handler is a group of handlers within a route.
 type Handler struct {
 	logger *log.Logger
@@ -48,9 +75,26 @@ func NewHandler(logger *log.Logger, db *sql.DB) Handler {
 // Routes initializes all the routes with their http handlers
 func (h Handler) Routes(e *echo.Group) {
 	e.GET("", h.Find)
+	e.GET("/presets", h.Presets)
 	e.GET("/:gameID", h.Retrieve)
 	e.POST("", h.Create)
 	e.PATCH("/:gameID", h.Apply)
+	e.POST("/:gameID/hint", h.Hint)
+	e.POST("/:gameID/join", h.Join)
+	e.GET("/:gameID/participants", h.Participants)
+	e.POST("/:gameID/participants", h.Invite)
+	e.POST("/:gameID/participants/accept", h.Accept)
+	e.DELETE("/:gameID/participants/:playerID", h.Revoke)
+	e.POST("/:gameID/pause", h.Pause)
+	e.POST("/:gameID/resume", h.Resume)
+	e.POST("/:gameID/undo", h.Undo)
+	e.POST("/:gameID/resign", h.Resign)
+	e.DELETE("/:gameID", h.Delete)
+	e.GET("/:gameID/replay", h.Replay)
+	e.GET("/:gameID/export", h.Export)
+	e.POST("/import", h.Import)
+	e.GET("/:gameID/socket", h.Socket)
+	e.GET("/:gameID/events", h.Events)
 
 }
 
@@ -94,6 +138,17 @@ func (h Handler) Retrieve(c echo.Context) error {
 	return response.NewSuccessResponse(c, sgResponse{game})
 }
 
+// Presets is the http handler that lists the game presets
+func (h Handler) Presets(c echo.Context) error {
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	presets, err := api.FindPresets(ctx)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, psResponse{presets})
+}
+
 // Create is the http handler that creates a game
 func (h Handler) Create(c echo.Context) error {
 	user, err := security.JWTDecode(c)
@@ -105,7 +160,7 @@ func (h Handler) Create(c echo.Context) error {
 	err = c.Bind(&pGame)
 	if err != nil {
 		h.logger.Printf("could not bind request data%v\n", err)
-		return response.NewBadRequestResponse(c, "rows, cols, mines and private are required")
+		return response.NewBadRequestResponse(c, "a preset or rows, cols and mines are required")
 	}
 	if err = c.Validate(pGame); err != nil {
 		h.logger.Printf("validation error %v\n", err)
@@ -145,3 +200,387 @@ func (h Handler) Apply(c echo.Context) error {
 	}
 	return response.NewSuccessResponse(c, cResponse{confirmation})
 }
+
+// Hint is the http handler that suggests a move on a game
+func (h Handler) Hint(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	hint, err := api.Hint(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, hResponse{hint})
+}
+
+// Join is the http handler that adds a player to the turn order of a turn based game
+func (h Handler) Join(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	err = api.JoinGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, sgResponse{statefulGame})
+}
+
+// Participants is the http handler that lists the players invited to a game
+func (h Handler) Participants(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	participants, err := api.FindParticipants(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, ptsResponse{participants})
+}
+
+// Invite is the http handler that invites a player to play or watch a game
+func (h Handler) Invite(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	invitation := Invitation{}
+	err = c.Bind(&invitation)
+	if err != nil {
+		h.logger.Printf("could not bind request data%v\n", err)
+		return response.NewBadRequestResponse(c, "playerId and role are required")
+	}
+	if err = c.Validate(invitation); err != nil {
+		h.logger.Printf("validation error %v\n", err)
+		return response.NewBadRequestResponse(c, err.Error())
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	participant, err := api.InviteParticipant(ctx, user, gameID, invitation)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, ptResponse{participant})
+}
+
+// Accept is the http handler that accepts the invitation of a player to a game
+func (h Handler) Accept(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	err = api.AcceptInvitation(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, sgResponse{statefulGame})
+}
+
+// Revoke is the http handler that takes away the access of a player to a game
+func (h Handler) Revoke(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	playerIDStr := c.Param("playerID")
+	playerID, err := strconv.ParseInt(playerIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("player %s does not participate in the game", playerIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	err = api.RevokeParticipant(ctx, user, gameID, playerID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, nil)
+}
+
+// Pause is the http handler that stops the clock of a private game
+func (h Handler) Pause(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	statefulGame, err := api.PauseGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, sgResponse{statefulGame})
+}
+
+// Resume is the http handler that restarts the clock of a paused game
+func (h Handler) Resume(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	statefulGame, err := api.ResumeGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, sgResponse{statefulGame})
+}
+
+// Undo is the http handler that takes back the last operation of a casual game
+func (h Handler) Undo(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	confirmation, err := api.UndoOperation(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, cResponse{confirmation})
+}
+
+// Resign is the http handler that finishes a game given up by its creator
+func (h Handler) Resign(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	status, err := api.ResignGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, stResponse{status})
+}
+
+// Delete is the http handler that removes a game, or archives it when the archive query param is true
+func (h Handler) Delete(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	archive := c.QueryParam("archive") == "true"
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	err = api.DeleteGame(ctx, user, gameID, archive)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, nil)
+}
+
+// Replay is the http handler that retrieves the history of a finished game
+func (h Handler) Replay(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	replay, err := api.ReplayGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, rpResponse{replay})
+}
+
+// Export is the http handler that downloads a finished game as a game file, in its compact text form when the
+// format query param is text or else as JSON
+func (h Handler) Export(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	f, err := api.ExportGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	if c.QueryParam("format") == "text" {
+		var buf bytes.Buffer
+		err = gamefile.WriteText(&buf, f)
+		if err != nil {
+			h.logger.Printf("error writing game file: %v\n", err)
+			return response.NewResponseFromError(c, err)
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"game-%d.txt\"", gameID))
+		return c.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, buf.Bytes())
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"game-%d.json\"", gameID))
+	return c.JSON(http.StatusOK, f)
+}
+
+// Import is the http handler that recreates a finished game from a game file, the file is read in its compact
+// text form when the request content type is text/plain or else as JSON
+func (h Handler) Import(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	var f gamefile.File
+	body := c.Request().Body
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMETextPlain) {
+		f, err = gamefile.ReadText(body)
+	} else {
+		f, err = gamefile.ReadJSON(body)
+	}
+	if err != nil {
+		h.logger.Printf("could not read game file %v\n", err)
+		return response.NewBadRequestResponse(c, err.Error())
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	// an imported game is private unless it is imported with the private query param set to false
+	gameID, err := api.ImportGame(ctx, user, f, c.QueryParam("private") != "false")
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	return response.NewSuccessResponse(c, sgResponse{statefulGame})
+}
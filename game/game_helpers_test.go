@@ -191,6 +191,9 @@ func assertStatus(s1, s2 Status) error {
 	if s1.Lost != s2.Lost {
 		return fmt.Errorf("expected status Lost to be %v but was %v", s1.Lost, s2.Lost)
 	}
+	if s1.Resigned != s2.Resigned {
+		return fmt.Errorf("expected status Resigned to be %v but was %v", s1.Resigned, s2.Resigned)
+	}
 	if s1.Rows != s2.Rows {
 		return fmt.Errorf("expected status Rows to be %v but was %v", s1.Rows, s2.Rows)
 	}
@@ -259,7 +262,7 @@ func assertGameTests(ctx context.Context, t testing.TB, user security.JWTUser, a
 					t.Fatalf("test %d, failed: error game was not marked as finished\n", i)
 				}
 			}
-			board, err := retrieveFullBoard(ctx, api.db, test.game.ID, int(test.game.Rows), int(test.game.Cols))
+			board, err := retrieveFullBoard(ctx, api.db, test.game.ID, 1, int(test.game.Rows), int(test.game.Cols))
 			if err != nil {
 				t.Fatalf("test %d failed: error retrieving game board %v\n", i, err)
 			}
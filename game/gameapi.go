@@ -6,26 +6,39 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"net/http"
 	"strings"
 	"time"
 
-	extErrors "github.com/pkg/errors"
-
 	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/gamefile"
 	"github.com/javiercbk/minesweeper/http/response"
 	"github.com/javiercbk/minesweeper/http/security"
 	"github.com/javiercbk/minesweeper/models"
-	"github.com/lib/pq"
+	"github.com/javiercbk/minesweeper/solver"
+	"github.com/javiercbk/minesweeper/topology"
 	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 	"github.com/volatiletech/sqlboiler/queries"
 	"github.com/volatiletech/sqlboiler/queries/qm"
 )
 
-// uniqueGameOperationConstaintName is the constraint that ensures that operations are unique within a game
-const uniqueGameOperationConstaintName = "idx_game_operation"
+// accessibleGame is the condition of the games that a player can access: every public game and the games where
+// the player is an accepted participant
+const accessibleGame = "(games.private = false OR EXISTS (SELECT 1 FROM game_participants gp WHERE gp.game_id = games.id AND gp.player_id = ? AND gp.accepted_at IS NOT NULL))"
+
+// GeneratorVersion is the current version of the board generator. It must be increased on any change
+// that alters the board generated from a seed, so old seeds can still be reproduced.
+const GeneratorVersion = 1
+
+// hintMineProximity is the mine proximity recorded with every hint. A hinted point is never revealed, so it is
+// recorded as an unrevealed point and the mines of the board stay hidden in the replays and exports of the game.
+const hintMineProximity = -1
+
+// maxLayers is the maximum depth of a board
+const maxLayers = 10
 
 const (
 	// StateNotRevealed is an integer sent to the client that means that the point in space is not revealed
@@ -53,29 +66,141 @@ const (
 // ErrInvalidRowCols is returned when given a zero or negative row or column count
 var ErrInvalidRowCols = errors.New("invalid row or column count")
 
-// ErrTooManyMines is returned when the amount of mines is greater or equal than the board
+// ErrInvalidLayers is returned when given a zero, negative or too big layer count
+var ErrInvalidLayers = errors.New("invalid layer count")
+
+// ErrTooManyMines is returned when the amount of mines is greater or equal than the board or than the amount
+// that can be stored
 var ErrTooManyMines = errors.New("too many mines")
 
 // ErrNoneMines is returned when the amount of mines is negative or zero
 var ErrNoneMines = errors.New("none mines")
 
+// ErrUnknownPreset is returned when the game preset does not exist
+var ErrUnknownPreset = errors.New("unknown game preset")
+
+// ErrUnknownTopology is returned when the board topology does not exist
+var ErrUnknownTopology = errors.New("unknown board topology")
+
+// topologies are the neighbours functions of every board topology
+var topologies = map[string]topology.Neighbours{
+	models.BoardTopologySquare: topology.Square,
+	models.BoardTopologyHex:    topology.Hex,
+}
+
+// wrappedTopologies are the neighbours functions of every board topology when the edges of the board wrap
+var wrappedTopologies = map[string]topology.Neighbours{
+	models.BoardTopologySquare: topology.SquareTorus,
+	models.BoardTopologyHex:    topology.HexTorus,
+}
+
+// ErrOddWrappedHexRows is returned when a wrapped hex board has an odd amount of rows
+var ErrOddWrappedHexRows = errors.New("a wrapped hex board must have an even amount of rows")
+
+// ErrInvalidLayout is returned when a mine of a board layout is outside of the board or repeated
+var ErrInvalidLayout = errors.New("invalid board layout")
+
+// ErrLayoutMines is returned when the amount of mines of a game does not match its board layout
+var ErrLayoutMines = errors.New("the amount of mines does not match the board layout")
+
+// ErrMineProximityMismatch is returned when the mine proximities of an imported game do not match its mines
+var ErrMineProximityMismatch = errors.New("the mine proximities do not match the mines of the board")
+
+// ErrNoGuessLayout is returned when a game with a board layout is a no guess game
+var ErrNoGuessLayout = errors.New("a board layout cannot be a no guess board")
+
+// ErrUnsupportedLayers is returned when a board with several layers uses a variant only supported by flat boards
+var ErrUnsupportedLayers = errors.New("a board with several layers must be square, cannot wrap and cannot be a no guess board")
+
+// ErrUnknownGeneratorVersion is returned when the board generator version does not exist
+var ErrUnknownGeneratorVersion = errors.New("unknown board generator version")
+
 // ErrGameNotExists is returned when attempting to make an operation with a game that does not exists
 var ErrGameNotExists = errors.New("the game does not exists")
 
 // ErrGameFinished is returned when attempting to apply an operation on a concluded game
 var ErrGameFinished = errors.New("the game has finished")
 
+// ErrGamePaused is returned when attempting to play or pause a paused game
+var ErrGamePaused = errors.New("the game is paused")
+
+// ErrGameNotPaused is returned when attempting to resume a game that is not paused
+var ErrGameNotPaused = errors.New("the game is not paused")
+
+// ErrGameNotSolo is returned when attempting to pause a game that other players can play
+var ErrGameNotSolo = errors.New("only private games without other players can be paused")
+
+// ErrGameNotCasual is returned when attempting to undo an operation in a game that is not casual
+var ErrGameNotCasual = errors.New("only casual games allow undoing operations")
+
+// ErrNothingToUndo is returned when a game has no operation left to undo
+var ErrNothingToUndo = errors.New("there is no operation to undo")
+
+// ErrUndoNotAllowed is returned when the last operation of a game can not be undone by the player
+var ErrUndoNotAllowed = errors.New("only the last operation of a player that changed a single point can be undone")
+
+// ErrGameNotFinished is returned when attempting to replay a game that has not finished yet
+var ErrGameNotFinished = errors.New("the game has not finished yet")
+
+// errNothingToChord is returned when a chord operation does not reveal any point
+var errNothingToChord = errors.New("nothing to chord")
+
 // ProspectGame contains all the information needed to build a new game
 type ProspectGame struct {
-	ID      int64 `json:"id"`
-	Rows    int   `json:"rows" validate:"required,gte=0,lt=100"`
-	Cols    int   `json:"cols" validate:"required,gte=0,lt=100"`
-	Mines   int   `json:"mines" validate:"required,gt=0"`
-	Private bool  `json:"private"`
+	ID int64 `json:"id"`
+	// Preset takes the rows, cols and mines of the game from a named board configuration
+	Preset  string `json:"preset,omitempty"`
+	Rows    int    `json:"rows" validate:"gte=0,lt=100"`
+	Cols    int    `json:"cols" validate:"gte=0,lt=100"`
+	Mines   int    `json:"mines" validate:"gte=0"`
+	Private bool   `json:"private"`
+	// SafeFirstReveal moves the mines away from the first revealed point, it defaults to true
+	SafeFirstReveal *bool `json:"safeFirstReveal,omitempty"`
+	// Seed generates the board, it defaults to a random seed
+	Seed *int64 `json:"seed,omitempty"`
+	// GeneratorVersion defaults to the current generator version
+	GeneratorVersion int `json:"generatorVersion,omitempty" validate:"gte=0"`
+	// NoGuess places the mines so the board can be solved without guessing, it always has a safe first reveal
+	NoGuess bool `json:"noGuess"`
+	// Topology defaults to square
+	Topology string `json:"topology,omitempty"`
+	// Wrap connects the last row and column of the board with the first ones
+	Wrap bool `json:"wrap"`
+	// Layers defaults to a flat board with a single layer
+	Layers int `json:"layers,omitempty" validate:"gte=0,lte=10"`
+	// Layout places the mines in the given points instead of random ones. The board is not generated from a seed
+	// and SafeFirstReveal defaults to false, so the layout is played as it was designed
+	Layout []LayoutMine `json:"layout,omitempty"`
+	// Casual lets the players undo their last operations
+	Casual bool `json:"casual"`
+	// SpectateOnly lets only the creator play the game, the other players can watch it when it is public
+	SpectateOnly bool `json:"spectateOnly"`
+	// Competitive scores the points of every player, hitting a mine only eliminates the player
+	Competitive bool `json:"competitive"`
+	// TurnBased makes the players play one at a time
+	TurnBased bool `json:"turnBased"`
+	// TurnSeconds passes the turn of a turn based game once its time runs out
+	TurnSeconds int `json:"turnSeconds,omitempty" validate:"gte=0,lte=86400"`
+}
+
+// Preset is a named board configuration
+type Preset struct {
+	Name  string `boil:"name" json:"name"`
+	Rows  int16  `boil:"rows" json:"rows"`
+	Cols  int16  `boil:"cols" json:"cols"`
+	Mines int16  `boil:"mines" json:"mines"`
+}
+
+// LayoutMine is a mine of a hand-crafted board layout
+type LayoutMine struct {
+	Layer int `json:"layer"`
+	Row   int `json:"row"`
+	Col   int `json:"col"`
 }
 
 // OperationResult is the result of an minesweeper algebra operation application
 type OperationResult struct {
+	Layer         int `json:"layer"`
 	Row           int `json:"row"`
 	Col           int `json:"col"`
 	MineProximity int `json:"mineProximity"`
@@ -86,20 +211,31 @@ type OperationResult struct {
 type Operation struct {
 	ID      int                   `json:"id" validate:"required"`
 	GameID  int64                 `json:"gameId" validate:"required"`
-	Op      algebra.OperationType `json:"op" validate:"required,eq=1|eq=2"`
+	Op      algebra.OperationType `json:"op" validate:"required,eq=1|eq=2|eq=4"`
+	Layer   int                   `json:"layer" validate:"gte=0,lt=10"`
 	Row     int                   `json:"row" validate:"gte=0,lt=100"`
 	Col     int                   `json:"col" validate:"gte=0,lt=100"`
 	Applied bool                  `json:"applied"`
 	Result  []OperationResult     `json:"result,omitempty"`
 }
 
-// Status is the game status
+// Status is the game status. The rows of every layer of the board are stacked one after the other.
 type Status struct {
-	Rows  int     `json:"rows"`
-	Cols  int     `json:"cols"`
-	Won   bool    `json:"won"`
-	Lost  bool    `json:"lost"`
-	Board [][]int `json:"board,omitempty"`
+	Layers int  `json:"layers"`
+	Rows   int  `json:"rows"`
+	Cols   int  `json:"cols"`
+	Won    bool `json:"won"`
+	Lost   bool `json:"lost"`
+	// Resigned is set when the game was given up, a resigned game is neither won nor lost
+	Resigned bool `json:"resigned"`
+	// Elapsed is the playing time in milliseconds, it is only set when the game finishes
+	Elapsed int64   `json:"elapsed,omitempty"`
+	Board   [][]int `json:"board,omitempty"`
+	// Scores are the scores of the players of a competitive game
+	Scores []PlayerScore `json:"scores,omitempty"`
+	// Winner is the best player still standing when a competitive game is won by clearing its board. A
+	// competitive game is lost when every player was eliminated.
+	Winner *Creator `json:"winner,omitempty"`
 }
 
 // OperationConfirmation is the confirmation of an operation application
@@ -110,25 +246,106 @@ type OperationConfirmation struct {
 	Error           error       `json:"error"`
 }
 
+const (
+	// HintReveal is a hint of a point that is certainly safe
+	HintReveal = "reveal"
+	// HintMark is a hint of a point that certainly has a mine
+	HintMark = "mark"
+	// HintGuess is a hint of the least risky point when nothing can be deduced from the board
+	HintGuess = "guess"
+)
+
+// Hint is a move suggested to a player. Risk is the estimated probability of the point having a mine, which is
+// only greater than zero on a guess.
+type Hint struct {
+	OperationID int     `json:"operationId"`
+	Action      string  `json:"action"`
+	Layer       int     `json:"layer"`
+	Row         int     `json:"row"`
+	Col         int     `json:"col"`
+	Risk        float64 `json:"risk"`
+}
+
 // Creator is the basic data of a game creator
 type Creator struct {
 	ID   int64  `boil:"players.id" json:"id"`
 	Name string `boil:"players.name" json:"name"`
 }
 
+// Replay is the history of a finished game
+type Replay struct {
+	GameID   int64  `json:"gameId"`
+	Layers   int    `json:"layers"`
+	Rows     int    `json:"rows"`
+	Cols     int    `json:"cols"`
+	Mines    int    `json:"mines"`
+	Topology string `json:"topology"`
+	Wrap     bool   `json:"wrap"`
+	Won      bool   `json:"won"`
+	Resigned bool   `json:"resigned"`
+	// Layout has the mines the game was played with, which are the mines left after the first reveal moved them
+	// away in a game with a safe first reveal
+	Layout []LayoutMine `json:"layout"`
+	// Operations only contain the point a player acted on, the points revealed by an empty point or a chord are
+	// found by playing the operations on the layout
+	Operations []ReplayOperation `json:"operations"`
+}
+
+// ReplayOperation is an operation of a game replay with the player that made it and when it was made
+type ReplayOperation struct {
+	ID            int                   `boil:"operation_id" json:"id"`
+	Operation     string                `boil:"operation" json:"-"`
+	Op            algebra.OperationType `json:"op"`
+	Layer         int                   `boil:"layer" json:"layer"`
+	Row           int                   `boil:"row" json:"row"`
+	Col           int                   `boil:"col" json:"col"`
+	MineProximity int                   `boil:"mine_proximity" json:"-"`
+	Result        OperationResult       `json:"result"`
+	CreatedAt     null.Time             `boil:"created_at" json:"createdAt"`
+	Player        Creator               `boil:",bind" json:"player"`
+}
+
 // StatefulGame is a game with the revealed points of the board
 type StatefulGame struct {
-	ID              int64        `boil:"id" json:"id"`
-	Private         bool         `boil:"private" json:"private"`
-	Cols            int16        `boil:"cols" json:"cols"`
-	Rows            int16        `boil:"rows" json:"rows"`
-	Mines           int16        `boil:"mines" json:"mines"`
-	StartedAt       null.Time    `boil:"started_at" json:"startedAt,omitempty"`
-	FinishedAt      null.Time    `boil:"finished_at" json:"finishedAt,omitempty"`
-	Won             null.Bool    `boil:"won" json:"won,omitempty"`
-	Creator         Creator      `boil:",bind" json:"creator"`
-	LastOperationID null.Int     `boil:"last_operation_id" json:"lastOperationId"`
-	Board           [][]null.Int `json:"board"`
+	ID              int64     `boil:"id" json:"id"`
+	Private         bool      `boil:"private" json:"private"`
+	Cols            int16     `boil:"cols" json:"cols"`
+	Rows            int16     `boil:"rows" json:"rows"`
+	Mines           int16     `boil:"mines" json:"mines"`
+	StartedAt       null.Time `boil:"started_at" json:"startedAt,omitempty"`
+	FinishedAt      null.Time `boil:"finished_at" json:"finishedAt,omitempty"`
+	Won             null.Bool `boil:"won" json:"won,omitempty"`
+	Creator         Creator   `boil:",bind" json:"creator"`
+	LastOperationID null.Int  `boil:"last_operation_id" json:"lastOperationId"`
+	// Seed is only revealed after the game has finished
+	Seed             null.Int64    `boil:"seed" json:"seed,omitempty"`
+	GeneratorVersion int16         `boil:"generator_version" json:"generatorVersion"`
+	NoGuess          bool          `boil:"no_guess" json:"noGuess"`
+	Topology         string        `boil:"topology" json:"topology"`
+	Wrap             bool          `boil:"wrap" json:"wrap"`
+	Layers           int16         `boil:"layers" json:"layers"`
+	Preset           null.String   `boil:"preset" json:"preset,omitempty"`
+	PausedAt         null.Time     `boil:"paused_at" json:"pausedAt,omitempty"`
+	PausedMillis     int64         `boil:"paused_millis" json:"-"`
+	Elapsed          int64         `json:"elapsed"`
+	Casual           bool          `boil:"casual" json:"casual"`
+	Undos            int           `boil:"undos" json:"undos"`
+	Resigned         bool          `boil:"resigned" json:"resigned"`
+	Archived         bool          `boil:"archived" json:"archived"`
+	SpectateOnly     bool          `boil:"spectate_only" json:"spectateOnly"`
+	Competitive      bool          `boil:"competitive" json:"competitive"`
+	TurnBased        bool          `boil:"turn_based" json:"turnBased"`
+	TurnSeconds      int           `boil:"turn_seconds" json:"turnSeconds,omitempty"`
+	TurnPosition     int           `boil:"turn_position" json:"-"`
+	TurnStartedAt    null.Time     `boil:"turn_started_at" json:"turnStartedAt,omitempty"`
+	TurnOrder        []Creator     `json:"turnOrder,omitempty"`
+	CurrentPlayer    *Creator      `json:"currentPlayer,omitempty"`
+	Role             null.String   `boil:"role" json:"role,omitempty"`
+	Invited          bool          `boil:"invited" json:"invited,omitempty"`
+	Hints            int           `boil:"hints" json:"hints"`
+	Board            [][]null.Int  `json:"board"`
+	Scores           []PlayerScore `json:"scores,omitempty"`
+	Winner           *Creator      `json:"winner,omitempty"`
 }
 
 // API is the game api
@@ -137,6 +354,22 @@ type API interface {
 	ApplyOperation(ctx context.Context, user security.JWTUser, oper Operation) (OperationConfirmation, error)
 	FindGames(ctx context.Context, user security.JWTUser) ([]StatefulGame, error)
 	RetrieveGame(ctx context.Context, user security.JWTUser, id int64) (StatefulGame, error)
+	Hint(ctx context.Context, user security.JWTUser, id int64) (Hint, error)
+	JoinGame(ctx context.Context, user security.JWTUser, id int64) error
+	FindParticipants(ctx context.Context, user security.JWTUser, id int64) ([]Participant, error)
+	InviteParticipant(ctx context.Context, user security.JWTUser, id int64, invitation Invitation) (Participant, error)
+	AcceptInvitation(ctx context.Context, user security.JWTUser, id int64) error
+	RevokeParticipant(ctx context.Context, user security.JWTUser, id int64, playerID int64) error
+	PauseGame(ctx context.Context, user security.JWTUser, id int64) (StatefulGame, error)
+	ResumeGame(ctx context.Context, user security.JWTUser, id int64) (StatefulGame, error)
+	UndoOperation(ctx context.Context, user security.JWTUser, id int64) (OperationConfirmation, error)
+	ResignGame(ctx context.Context, user security.JWTUser, id int64) (Status, error)
+	DeleteGame(ctx context.Context, user security.JWTUser, id int64, archive bool) error
+	ReplayGame(ctx context.Context, user security.JWTUser, id int64) (Replay, error)
+	FindEvents(ctx context.Context, user security.JWTUser, id int64, afterID int) ([]Event, error)
+	ExportGame(ctx context.Context, user security.JWTUser, id int64) (gamefile.File, error)
+	ImportGame(ctx context.Context, user security.JWTUser, f gamefile.File, private bool) (int64, error)
+	FindPresets(ctx context.Context) ([]Preset, error)
 }
 
 type api struct {
@@ -157,11 +390,15 @@ type boardPoint struct {
 	col int
 }
 
+// board is a minesweeper board, the rows of every layer of a board with several layers are stacked one after
+// the other in its rows
 type board struct {
-	rows  int
-	cols  int
-	mines int
-	board [][]int
+	rows       int
+	cols       int
+	mines      int
+	layers     int
+	board      [][]int
+	neighbours topology.Neighbours
 }
 
 func (api api) FindGames(ctx context.Context, user security.JWTUser) ([]StatefulGame, error) {
@@ -171,10 +408,26 @@ func (api api) FindGames(ctx context.Context, user security.JWTUser) ([]Stateful
 		g.rows as "rows", g.cols as "cols",
 		g.mines as "mines", g.started_at as "started_at",
 		g.finished_at as "finished_at", g.won as "won",
+		CASE WHEN g.finished_at IS NOT NULL THEN g.seed END as "seed",
+		g.generator_version as "generator_version", g.no_guess as "no_guess", g.topology as "topology", g.wrap as "wrap",
+		g.layers as "layers", g.preset as "preset",
+		g.paused_at as "paused_at", g.paused_millis as "paused_millis",
+		g.casual as "casual", g.undos as "undos", g.resigned as "resigned", g.archived as "archived",
+		g.spectate_only as "spectate_only", g.competitive as "competitive",
+		g.turn_based as "turn_based", g.turn_seconds as "turn_seconds",
+		g.turn_position as "turn_position", g.turn_started_at as "turn_started_at",
+		(SELECT COUNT(*) FROM game_operations h WHERE h.game_id = g.id AND h.operation = 'hint') as "hints",
+		gp.role as "role", gp.id IS NOT NULL AND gp.accepted_at IS NULL as "invited",
 		p.id as "players.id", p.name as "players.name"
 		FROM games g INNER JOIN players p on g.creator_id = p.id
-		WHERE (g.private = false OR g.creator_id = $1)`, user.ID,
+		LEFT JOIN game_participants gp on gp.game_id = g.id AND gp.player_id = $1
+		WHERE (g.private = false OR gp.id IS NOT NULL) AND g.archived = false`, user.ID,
 	).Bind(ctx, api.db, &statefulGames)
+	now := time.Now().UTC()
+	for i := range statefulGames {
+		g := &statefulGames[i]
+		g.Elapsed = elapsedMillis(g.StartedAt, g.FinishedAt, g.PausedAt, g.PausedMillis, now)
+	}
 	return statefulGames, err
 }
 
@@ -185,11 +438,22 @@ func (api api) RetrieveGame(ctx context.Context, user security.JWTUser, id int64
 		g.rows as "rows", g.cols as "cols",
 		g.mines as "mines", g.started_at as "started_at",
 		g.finished_at as "finished_at", g.won as "won",
+		CASE WHEN g.finished_at IS NOT NULL THEN g.seed END as "seed",
+		g.generator_version as "generator_version", g.no_guess as "no_guess", g.topology as "topology", g.wrap as "wrap",
+		g.layers as "layers", g.preset as "preset",
+		g.paused_at as "paused_at", g.paused_millis as "paused_millis",
+		g.casual as "casual", g.undos as "undos", g.resigned as "resigned", g.archived as "archived",
+		g.spectate_only as "spectate_only", g.competitive as "competitive",
+		g.turn_based as "turn_based", g.turn_seconds as "turn_seconds",
+		g.turn_position as "turn_position", g.turn_started_at as "turn_started_at",
+		(SELECT COUNT(*) FROM game_operations h WHERE h.game_id = g.id AND h.operation = 'hint') as "hints",
+		gp.role as "role",
 		p.id as "players.id", p.name as "players.name",
 		go.operation_id as "last_operation_id"
 		FROM games g INNER JOIN players p on g.creator_id = p.id
+		LEFT OUTER JOIN game_participants gp ON gp.game_id = g.id AND gp.player_id = $2 AND gp.accepted_at IS NOT NULL
 		LEFT OUTER JOIN game_operations go ON go.game_id = g.id
-		WHERE g.id = $1 AND (g.private = false OR g.creator_id = $2)
+		WHERE g.id = $1 AND (g.private = false OR gp.id IS NOT NULL)
 		AND (go.operation_id IS NULL OR go.operation_id = (
 			SELECT MAX(o.operation_id)
 			FROM game_operations o
@@ -206,7 +470,35 @@ func (api api) RetrieveGame(ctx context.Context, user security.JWTUser, id int64
 		api.logger.Printf("error retrieving game: %v", err)
 		return statefulGame, err
 	}
-	gameBoardPoints, err := retrieveNullableBoard(ctx, api.db, id, int(statefulGame.Rows), int(statefulGame.Cols), pRevealed)
+	statefulGame.Elapsed = elapsedMillis(statefulGame.StartedAt, statefulGame.FinishedAt, statefulGame.PausedAt, statefulGame.PausedMillis, time.Now().UTC())
+	if statefulGame.Competitive {
+		statefulGame.Scores, err = retrieveScores(ctx, api.db, id)
+		if err != nil {
+			api.logger.Printf("error retrieving game scores: %v", err)
+			return statefulGame, err
+		}
+		if statefulGame.Won.Bool {
+			statefulGame.Winner = winner(statefulGame.Scores)
+		}
+	}
+	if statefulGame.TurnBased {
+		statefulGame.TurnOrder, err = retrieveTurnOrder(ctx, api.db, id)
+		if err != nil {
+			api.logger.Printf("error retrieving game turn order: %v", err)
+			return statefulGame, err
+		}
+		if !statefulGame.FinishedAt.Valid && len(statefulGame.TurnOrder) > 0 {
+			position, startedAt := currentTurn(statefulGame.TurnPosition, len(statefulGame.TurnOrder), statefulGame.TurnStartedAt.Time, turnLimit(statefulGame.TurnSeconds), time.Now().UTC())
+			currentPlayer := statefulGame.TurnOrder[position]
+			statefulGame.CurrentPlayer = &currentPlayer
+			statefulGame.TurnStartedAt = null.TimeFrom(startedAt)
+		}
+	}
+	if statefulGame.PausedAt.Valid {
+		// the board stays hidden while the game is paused
+		return statefulGame, nil
+	}
+	gameBoardPoints, err := retrieveNullableBoard(ctx, api.db, id, int(statefulGame.Layers), int(statefulGame.Rows), int(statefulGame.Cols), pRevealed)
 	if err != nil {
 		return statefulGame, err
 	}
@@ -214,9 +506,402 @@ func (api api) RetrieveGame(ctx context.Context, user security.JWTUser, id int64
 	return statefulGame, err
 }
 
+// Hint suggests a move to a player and records it as a hint operation of the game
+func (api api) Hint(ctx context.Context, user security.JWTUser, id int64) (Hint, error) {
+	var hint Hint
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+	).One(ctx, api.db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return hint, response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving game: %v", err)
+		return hint, err
+	}
+	if game.FinishedAt.Valid {
+		return hint, response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrGameFinished.Error(),
+		}
+	}
+	participant, err := api.retrieveParticipant(ctx, game.ID, user)
+	if err != nil {
+		return hint, err
+	}
+	if isSpectator(game, participant) {
+		return hint, response.HTTPError{
+			Code:    http.StatusForbidden,
+			Message: ErrSpectator.Error(),
+		}
+	}
+	if game.PausedAt.Valid {
+		return hint, response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrGamePaused.Error(),
+		}
+	}
+	err = api.checkNotEliminated(ctx, api.db, game, user)
+	if err != nil {
+		return hint, err
+	}
+	err = api.checkTurn(ctx, game, user)
+	if err != nil {
+		return hint, err
+	}
+	hint, err = api.recordHint(ctx, user, id)
+	if err == nil {
+		events.publish(id, hintEvent(id, hint))
+	}
+	return hint, err
+}
+
+// recordHint finds a hint on the board of the locked game and stores it as the next operation of the game
+func (api api) recordHint(ctx context.Context, user security.JWTUser, gameID int64) (Hint, error) {
+	tx, err := api.db.BeginTx(ctx, nil)
+	if err != nil {
+		api.logger.Printf("error beggining transaction for recording hint: %v\n", err)
+		return Hint{}, err
+	}
+	hint, err := api.recordLockedHint(ctx, tx, user, gameID)
+	if err != nil {
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back hint with error: %v\n", rollbackError)
+		}
+		return hint, err
+	}
+	return hint, tx.Commit()
+}
+
+// recordLockedHint locks the game, so the hint is found on the board the operations ahead of it left and it takes
+// the next operation id after them, and stores the hint within the transaction
+func (api api) recordLockedHint(ctx context.Context, tx *sql.Tx, user security.JWTUser, gameID int64) (Hint, error) {
+	var hint Hint
+	game, err := lockGame(ctx, tx, gameID)
+	if err != nil {
+		api.logger.Printf("error locking game: %v\n", err)
+		return hint, err
+	}
+	if game.FinishedAt.Valid {
+		// one of the operations ahead of the hint finished the game
+		return hint, response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrGameFinished.Error(),
+		}
+	}
+	fullBoard, err := retrieveFullBoard(ctx, tx, gameID, int(game.Layers), int(game.Rows), int(game.Cols))
+	if err != nil {
+		api.logger.Printf("error retrieving game board: %v\n", err)
+		return hint, err
+	}
+	b := gameBoard(game, fullBoard)
+	hint, err = newHint(b.board, b.mines, b.neighbours)
+	if err != nil {
+		api.logger.Printf("error solving game %d: %v\n", gameID, err)
+		return hint, err
+	}
+	hint.Layer, hint.Row = b.layerPoint(hint.Row)
+	operationID := 1
+	lastOperation, err := models.GameOperations(
+		qm.Select("operation_id"),
+		qm.Where("game_id = ?", gameID),
+		qm.OrderBy("operation_id DESC"),
+	).One(ctx, tx)
+	if err == nil {
+		operationID = lastOperation.OperationID + 1
+	} else if err != sql.ErrNoRows {
+		api.logger.Printf("error retrieving last game operation: %v\n", err)
+		return hint, err
+	}
+	hintOperation := &models.GameOperation{
+		GameID:        gameID,
+		Layer:         int16(hint.Layer),
+		Row:           int16(hint.Row),
+		Col:           int16(hint.Col),
+		PlayerID:      user.ID,
+		MineProximity: hintMineProximity,
+		OperationID:   operationID,
+		Operation:     models.MineOperationHint,
+	}
+	err = hintOperation.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		api.logger.Printf("error inserting hint operation: %v\n", err)
+		return hint, err
+	}
+	hint.OperationID = operationID
+	return hint, nil
+}
+
+// newHint finds a safe point to reveal, or else a mine that is not marked yet, or else the least risky point
+func newHint(fullBoard [][]int, mines int, neighbours topology.Neighbours) (Hint, error) {
+	var hint Hint
+	visible := make([][]null.Int, len(fullBoard))
+	for r := range fullBoard {
+		visible[r] = make([]null.Int, len(fullBoard[r]))
+		for c, mp := range fullBoard[r] {
+			if mp >= 0 {
+				visible[r][c] = null.IntFrom(mp)
+			}
+		}
+	}
+	s, err := solver.NewWithTopology(visible, nil, mines, neighbours)
+	if err != nil {
+		return hint, err
+	}
+	solution, err := s.Solve()
+	if err != nil {
+		return hint, err
+	}
+	if len(solution.Safe) > 0 {
+		hint.Action = HintReveal
+		hint.Row = solution.Safe[0].Row
+		hint.Col = solution.Safe[0].Col
+		return hint, nil
+	}
+	for _, p := range solution.Mines {
+		// the points already marked as mines do not need a hint
+		if proximityToState(fullBoard[p.Row][p.Col]) != StateMarkedMine {
+			hint.Action = HintMark
+			hint.Row = p.Row
+			hint.Col = p.Col
+			return hint, nil
+		}
+	}
+	p, risk, err := s.Guess()
+	if err != nil {
+		return hint, err
+	}
+	hint.Action = HintGuess
+	hint.Row = p.Row
+	hint.Col = p.Col
+	hint.Risk = risk
+	return hint, nil
+}
+
+// PauseGame stops the clock of a private game and hides its board until the game is resumed
+func (api api) PauseGame(ctx context.Context, user security.JWTUser, id int64) (StatefulGame, error) {
+	game, err := api.retrieveSoloGame(ctx, user, id)
+	if err != nil {
+		return StatefulGame{}, err
+	}
+	gamePausedError := response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrGamePaused.Error(),
+	}
+	if game.PausedAt.Valid {
+		return StatefulGame{}, gamePausedError
+	}
+	affected, err := models.Games(qm.Where("id = ? AND paused_at IS NULL AND finished_at IS NULL", id)).
+		UpdateAll(ctx, api.db, models.M{"paused_at": time.Now().UTC()})
+	if err != nil {
+		api.logger.Printf("error pausing game %d: %v\n", id, err)
+		return StatefulGame{}, err
+	}
+	if affected == 0 {
+		// the game was paused or finished by another request
+		return StatefulGame{}, gamePausedError
+	}
+	return api.RetrieveGame(ctx, user, id)
+}
+
+// ResumeGame restarts the clock of a paused game, the time spent paused does not count as playing time
+func (api api) ResumeGame(ctx context.Context, user security.JWTUser, id int64) (StatefulGame, error) {
+	game, err := api.retrieveSoloGame(ctx, user, id)
+	if err != nil {
+		return StatefulGame{}, err
+	}
+	gameNotPausedError := response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrGameNotPaused.Error(),
+	}
+	if !game.PausedAt.Valid {
+		return StatefulGame{}, gameNotPausedError
+	}
+	pausedMillis := game.PausedMillis
+	if game.StartedAt.Valid {
+		// the clock was not ticking if the game was paused before the first operation
+		pausedMillis += durationMillis(time.Now().UTC().Sub(game.PausedAt.Time))
+	}
+	affected, err := models.Games(qm.Where("id = ? AND paused_at = ?", id, game.PausedAt.Time)).
+		UpdateAll(ctx, api.db, models.M{"paused_at": nil, "paused_millis": pausedMillis})
+	if err != nil {
+		api.logger.Printf("error resuming game %d: %v\n", id, err)
+		return StatefulGame{}, err
+	}
+	if affected == 0 {
+		// the game was resumed by another request
+		return StatefulGame{}, gameNotPausedError
+	}
+	return api.RetrieveGame(ctx, user, id)
+}
+
+// retrieveSoloGame retrieves an unfinished private game that no other player can play
+func (api api) retrieveSoloGame(ctx context.Context, user security.JWTUser, id int64) (*models.Game, error) {
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+	).One(ctx, api.db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving game: %v", err)
+		return nil, err
+	}
+	if game.FinishedAt.Valid {
+		return nil, response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrGameFinished.Error(),
+		}
+	}
+	if !game.Private {
+		return nil, response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrGameNotSolo.Error(),
+		}
+	}
+	err = api.checkOwner(ctx, id, user)
+	if err != nil {
+		return nil, err
+	}
+	if game.PausedAt.Valid {
+		// a paused game can always be resumed, even when a player joined it while it was paused
+		return game, nil
+	}
+	// an invited player can play the game as well
+	shared, err := models.GameParticipants(
+		qm.Where("game_id = ? AND player_id <> ? AND role = ? AND accepted_at IS NOT NULL", id, user.ID, models.ParticipantRolePlayer),
+	).Exists(ctx, api.db)
+	if err != nil {
+		api.logger.Printf("error retrieving game players: %v", err)
+		return nil, err
+	}
+	if shared {
+		return nil, response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrGameNotSolo.Error(),
+		}
+	}
+	return game, nil
+}
+
+// FindPresets retrieves every game preset ordered by board size
+func (api api) FindPresets(ctx context.Context) ([]Preset, error) {
+	presets := []Preset{}
+	err := models.GamePresets(
+		qm.Select("name", "rows", "cols", "mines"),
+		qm.OrderBy("rows * cols, mines, name"),
+	).Bind(ctx, api.db, &presets)
+	if err != nil {
+		api.logger.Printf("error retrieving game presets: %v\n", err)
+	}
+	return presets, err
+}
+
 // CreateGame creates a random board game and stores a new game in the database
 func (api api) CreateGame(ctx context.Context, user security.JWTUser, pGame *ProspectGame) error {
-	board, err := NewBoard(pGame.Rows, pGame.Cols, pGame.Mines)
+	if pGame.Preset != "" {
+		preset, err := models.GamePresets(qm.Where("name = ?", pGame.Preset)).One(ctx, api.db)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return response.HTTPError{
+					Code:    http.StatusBadRequest,
+					Message: ErrUnknownPreset.Error(),
+				}
+			}
+			api.logger.Printf("error retrieving game preset: %v\n", err)
+			return err
+		}
+		pGame.Rows = int(preset.Rows)
+		pGame.Cols = int(preset.Cols)
+		pGame.Mines = int(preset.Mines)
+	}
+	seed := time.Now().UTC().UnixNano()
+	if pGame.Seed != nil {
+		seed = *pGame.Seed
+	}
+	generatorVersion := GeneratorVersion
+	if pGame.GeneratorVersion != 0 {
+		generatorVersion = pGame.GeneratorVersion
+	}
+	boardTopology := models.BoardTopologySquare
+	if pGame.Topology != "" {
+		boardTopology = pGame.Topology
+	}
+	neighbours, ok := boardNeighbours(boardTopology, pGame.Wrap)
+	if !ok {
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrUnknownTopology.Error(),
+		}
+	}
+	if pGame.Wrap && boardTopology == models.BoardTopologyHex && pGame.Rows%2 != 0 {
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrOddWrappedHexRows.Error(),
+		}
+	}
+	layers := 1
+	if pGame.Layers != 0 {
+		layers = pGame.Layers
+	}
+	if layers > 1 && (boardTopology != models.BoardTopologySquare || pGame.Wrap || pGame.NoGuess) {
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrUnsupportedLayers.Error(),
+		}
+	}
+	if layers > 1 {
+		neighbours = topology.Cube(layers)
+	}
+	if pGame.Competitive && (pGame.Private || pGame.SpectateOnly || pGame.Casual) {
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrCompetitiveNotShared.Error(),
+		}
+	}
+	if pGame.TurnBased && (pGame.Private || pGame.SpectateOnly || pGame.Casual || pGame.Competitive) {
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrTurnBasedNotShared.Error(),
+		}
+	}
+	if !pGame.TurnBased {
+		// only the turns of a turn based game have a time limit
+		pGame.TurnSeconds = 0
+	}
+	safeFirstReveal := pGame.SafeFirstReveal == nil || *pGame.SafeFirstReveal || pGame.NoGuess
+	var board [][]int
+	var err error
+	if len(pGame.Layout) > 0 {
+		if pGame.NoGuess {
+			err = ErrNoGuessLayout
+		} else if pGame.Mines != len(pGame.Layout) {
+			err = ErrLayoutMines
+		} else {
+			board, err = NewLayoutBoard(layers, pGame.Rows, pGame.Cols, pGame.Layout, neighbours)
+		}
+		// a layout is not generated, so it cannot be reproduced from a seed
+		seed = 0
+		generatorVersion = 0
+		safeFirstReveal = pGame.SafeFirstReveal != nil && *pGame.SafeFirstReveal
+	} else if layers > 1 {
+		board, err = NewLayeredBoard(layers, pGame.Rows, pGame.Cols, pGame.Mines, seed, generatorVersion)
+	} else {
+		// the mines of a no guess board are placed again around the first reveal
+		board, err = NewSeededBoard(pGame.Rows, pGame.Cols, pGame.Mines, seed, generatorVersion, neighbours)
+		if err == nil && pGame.NoGuess {
+			// every first reveal would fail with too many mines, so the center of the board is tried first
+			_, err = NewNoGuessBoard(pGame.Rows, pGame.Cols, pGame.Mines, seed, generatorVersion, neighbours, pGame.Rows/2, pGame.Cols/2)
+		}
+	}
 	if err != nil {
 		return response.HTTPError{
 			Code:    http.StatusBadRequest,
@@ -224,17 +909,34 @@ func (api api) CreateGame(ctx context.Context, user security.JWTUser, pGame *Pro
 		}
 	}
 	game := &models.Game{
-		Rows:      int16(pGame.Rows),
-		Cols:      int16(pGame.Cols),
-		Mines:     int16(pGame.Mines),
-		CreatorID: user.ID,
-		Private:   pGame.Private,
+		Rows:             int16(pGame.Rows),
+		Cols:             int16(pGame.Cols),
+		Mines:            int16(pGame.Mines),
+		CreatorID:        user.ID,
+		Private:          pGame.Private,
+		SafeFirstReveal:  safeFirstReveal,
+		Seed:             seed,
+		GeneratorVersion: int16(generatorVersion),
+		NoGuess:          pGame.NoGuess,
+		Topology:         boardTopology,
+		Wrap:             pGame.Wrap,
+		Layers:           int16(layers),
+		Preset:           null.NewString(pGame.Preset, pGame.Preset != ""),
+		Casual:           pGame.Casual,
+		SpectateOnly:     pGame.SpectateOnly,
+		Competitive:      pGame.Competitive,
+		TurnBased:        pGame.TurnBased,
+		TurnSeconds:      pGame.TurnSeconds,
 	}
 	err = api.storeGameBoard(ctx, user, game, board)
 	if err != nil {
 		return err
 	}
 	pGame.ID = game.ID
+	pGame.SafeFirstReveal = &safeFirstReveal
+	pGame.GeneratorVersion = generatorVersion
+	pGame.Topology = boardTopology
+	pGame.Layers = layers
 	return nil
 }
 
@@ -244,11 +946,13 @@ func (api api) ApplyOperation(ctx context.Context, user security.JWTUser, oper O
 	confirmation := OperationConfirmation{
 		Operation: Operation{
 			GameID: oper.GameID,
+			Layer:  oper.Layer,
 			Row:    oper.Row,
 			Col:    oper.Col,
 		},
 	}
-	confirmationChan := make(chan OperationConfirmation)
+	// the confirmation is buffered so the operation does not block when nobody waits for it after a timeout
+	confirmationChan := make(chan OperationConfirmation, 1)
 	go api.applyOperation(ctx, user, oper, confirmationChan)
 	// attempt to apply the operation within a timeout
 	select {
@@ -275,8 +979,9 @@ func (api api) applyOperation(ctx context.Context, user security.JWTUser, oper O
 	confirmation := OperationConfirmation{
 		Operation: oper,
 	}
+	var participant *models.GameParticipant
 	game, err := models.Games(
-		qm.Where("id = ? AND (private = false OR creator_id = ?)", oper.GameID, user.ID),
+		qm.Where("id = ? AND "+accessibleGame, oper.GameID, user.ID),
 	).One(ctx, api.db)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -289,196 +994,1077 @@ func (api api) applyOperation(ctx context.Context, user security.JWTUser, oper O
 			Code:    http.StatusNotFound,
 			Message: ErrGameFinished.Error(),
 		}
-	} else {
+	} else if participant, err = api.retrieveParticipant(ctx, game.ID, user); err != nil {
+		// the participation of the player could not be retrieved
+	} else if isSpectator(game, participant) {
+		err = response.HTTPError{
+			Code:    http.StatusForbidden,
+			Message: ErrSpectator.Error(),
+		}
+	} else if game.PausedAt.Valid {
+		err = response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrGamePaused.Error(),
+		}
+	} else if err = api.checkNotEliminated(ctx, api.db, game, user); err != nil {
+		// the player hit a mine
+	} else if err = api.checkTurn(ctx, game, user); err == nil {
+		confirmation.Status.Layers = int(game.Layers)
 		confirmation.Status.Rows = int(game.Rows)
 		confirmation.Status.Cols = int(game.Cols)
-		err = api.attempApplyOperation(ctx, user, oper, &confirmation)
+		err = api.attempApplyOperation(ctx, user, game, oper, &confirmation)
 	}
 	if err != nil {
 		confirmation.Error = err
+	} else if confirmation.Operation.Applied {
+		events.publish(oper.GameID, confirmationEvent(confirmation))
 	}
 	confirmationChan <- confirmation
 }
 
-func (api api) attempApplyOperation(ctx context.Context, user security.JWTUser, oper Operation, confirmation *OperationConfirmation) error {
-	var gameOperations models.GameOperationSlice
-	var mineProximity algebra.MineProximity
-	operationID := oper.ID
-	clientOperation, err := algebra.NewOperation(oper.Op, oper.Row, oper.Col)
+// attempApplyOperation applies an operation after every operation of the game that arrived before it. The game is
+// locked until the operation is committed, so the operations of a game are applied one at a time, in the order
+// they arrive, and an operation never has to be retried because another one was committed in between.
+func (api api) attempApplyOperation(ctx context.Context, user security.JWTUser, game *models.Game, oper Operation, confirmation *OperationConfirmation) error {
+	clientOperation, err := algebra.NewLayeredOperation(oper.Op, oper.Layer, oper.Row, oper.Col)
 	if err != nil {
 		return err
 	}
-	// while context is not done attempt this operation
-	// WARNING: This algorithm might lead to client starvation,
-	// meaning that if several players are playing, the game might get bogged down with timeouts on operations
-	// clashing within themselves
-	for ctx.Err() == nil {
-		// step 1 => check if there are older operations to apply
-		gameOperations, err = models.GameOperations(
-			qm.Where("game_id = ? and operation_id >= ?", oper.GameID, operationID),
-			qm.OrderBy("operation_id ASC"),
-		).All(ctx, api.db)
-		if err != nil && err != sql.ErrNoRows {
-			return err
-		}
-		// step 2 check if there are older, unapplied operations, that invalidate this operation
-		opApplied := true
-		serverOperationsLen := len(gameOperations)
-		serverOperations := make([]algebra.Operation, serverOperationsLen)
-		deltaOperations := make([]Operation, serverOperationsLen)
-		newID := operationID
-		if serverOperationsLen > 0 {
-			newID = composeServerClient(gameOperations, oper.GameID, serverOperations, deltaOperations)
-			opApplied = algebra.ShouldOperationApply(serverOperations, clientOperation)
-		}
-		confirmation.DeltaOperations = deltaOperations
-		confirmation.Operation.GameID = oper.GameID
-		// step 3 => retrieve the current mine proximity value
-		mineProximity, err = api.retrieveRowCol(ctx, user, oper.GameID, oper.Row, oper.Col)
+	var noGuessBoard *board
+	if oper.Op == algebra.OpReveal && game.NoGuess && game.SafeFirstReveal && oper.Layer == 0 &&
+		oper.Row >= 0 && oper.Row < int(game.Rows) && oper.Col >= 0 && oper.Col < int(game.Cols) {
+		// the search is slow, so it is done before locking the game. The board found only depends on the game
+		// and the point, and a game can only stop waiting for its first reveal, never start again.
+		noGuessBoard, err = noGuessFirstReveal(int(game.Rows), int(game.Cols), int(game.Mines), gameNeighbours(game), game.Seed, oper.Row, oper.Col)
 		if err != nil {
-			if err == ErrInvalidRowCols {
-				err = response.HTTPError{
-					Code:    http.StatusBadRequest,
-					Message: err.Error(),
-				}
-			}
-			return err
-		}
-		if opApplied {
-			var newMineProximity algebra.MineProximity
-			// step 4a1 => apply the operation with to the current value
-			newMineProximity, err = clientOperation.Exec(mineProximity)
-			if err != nil {
-				// ErrOperationOutOfBounds
-				err = response.HTTPError{
-					Code:    http.StatusBadRequest,
-					Message: err.Error(),
-				}
-				return err
-			}
-			// step 4a2 => if the mine proximity is the same, after the operation, then don't apply the operation
-			if newMineProximity == mineProximity {
-				// operation had no action, mark as not applied
-				markOperationNotApplied(confirmation, mineProximity, oper)
-				break
-			} else {
-				// the mine proximity is different so the operation changes the actual value.
-				// commit the operation.
-				confirmation.Operation.ID = newID
-				err = api.commitOperation(ctx, user, confirmation, newMineProximity)
-				if err != nil {
-					cause := extErrors.Cause(err)
-					if pgerr, ok := cause.(*pq.Error); ok {
-						if pgerr.Constraint == uniqueGameOperationConstaintName {
-							// if the operation failed to be commited because the operation id is not unique
-							// it means that some operation was commited while this operation was being process.
-							// In such case retry the whole algorithm.
-							continue
-						}
-					}
-					return err
-				}
-				confirmation.Operation.Applied = true
-				confirmation.Operation.Result = []OperationResult{buildOperationResult(oper, newMineProximity)}
-				break
+			api.logger.Printf("error generating no guess board for game %d: %v\n", game.ID, err)
+			return response.HTTPError{
+				Code:    http.StatusConflict,
+				Message: ErrNoGuessFirstReveal.Error(),
 			}
-		} else {
-			// operation should not be applied
-			markOperationNotApplied(confirmation, mineProximity, oper)
-			break
 		}
 	}
-	return ctx.Err()
-}
-
-func (api api) commitOperation(ctx context.Context, user security.JWTUser, confirmation *OperationConfirmation, mineProximity algebra.MineProximity) error {
 	tx, err := api.db.BeginTx(ctx, nil)
 	if err != nil {
-		api.logger.Printf("error beggining transaction for updating game row: %v\n", err)
+		api.logger.Printf("error beggining transaction for applying operation: %v\n", err)
 		return err
 	}
-	err = api.updateRowCol(ctx, confirmation.Operation.GameID, confirmation.Operation.Row, confirmation.Operation.Col, mineProximity)
+	err = api.applyLockedOperation(ctx, tx, user, game, oper, clientOperation, noGuessBoard, confirmation)
 	if err != nil {
-		api.logger.Printf("error updating game row: %v. Rolling back operation insertion\n", err)
 		// just log rollback error
 		rollbackError := tx.Rollback()
 		if rollbackError != nil {
 			api.logger.Printf("error rolling back operation with error: %v\n", rollbackError)
 		}
+		if err == errNothingToChord {
+			// the chord was not applied
+			return nil
+		}
 		return err
 	}
-	newGameOperation := &models.GameOperation{
-		GameID:        confirmation.Operation.GameID,
-		Row:           int16(confirmation.Operation.Row),
-		Col:           int16(confirmation.Operation.Col),
-		PlayerID:      user.ID,
-		MineProximity: int16(mineProximity),
-		OperationID:   confirmation.Operation.ID,
-		Operation:     operationTypeStr(confirmation.Operation.Op),
+	err = tx.Commit()
+	if err != nil {
+		api.logger.Printf("error commiting operation: %v\n", err)
+		confirmation.Operation.Applied = false
 	}
-	err = newGameOperation.Insert(ctx, tx, boil.Infer())
+	return err
+}
+
+// applyLockedOperation locks the game and applies an operation within the transaction. The no guess board is the
+// board of a no guess game when the operation is its first reveal.
+func (api api) applyLockedOperation(ctx context.Context, tx *sql.Tx, user security.JWTUser, game *models.Game, oper Operation, clientOperation algebra.Operation, noGuessBoard *board, confirmation *OperationConfirmation) error {
+	// step 1 => wait for the operations of the game that arrived before this one
+	lockedGame, err := lockGame(ctx, tx, oper.GameID)
 	if err != nil {
-		api.logger.Printf("error inserting game operation: %v. Rolling back operation insertion\n", err)
-		// just log rollback error
-		rollbackError := tx.Rollback()
-		if rollbackError != nil {
-			api.logger.Printf("error rolling back operation with error: %v\n", rollbackError)
+		if err == sql.ErrNoRows {
+			return response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
 		}
+		api.logger.Printf("error locking game: %v\n", err)
 		return err
 	}
-	// check if the game status needs to be updated
-	if mineProximity == 9 {
-		confirmation.Status.Lost = true
-	} else if mineProximity >= 0 && mineProximity < 9 {
+	if lockedGame.FinishedAt.Valid {
+		// one of the operations that arrived before finished the game
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrGameFinished.Error(),
+		}
+	}
+	if lockedGame.PausedAt.Valid {
+		return response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrGamePaused.Error(),
+		}
+	}
+	// one of the operations that arrived before may have eliminated the player
+	err = api.checkNotEliminated(ctx, tx, lockedGame, user)
+	if err != nil {
+		return err
+	}
+	*game = *lockedGame
+	// step 2 => check if there are older operations to apply
+	gameOperations, err := models.GameOperations(
+		qm.Where("game_id = ? and operation_id >= ?", oper.GameID, oper.ID),
+		qm.OrderBy("operation_id ASC"),
+	).All(ctx, tx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	// step 3 => check if there are older, unapplied operations, that invalidate this operation
+	opApplied := true
+	serverOperationsLen := len(gameOperations)
+	serverOperations := make([]algebra.Operation, serverOperationsLen)
+	deltaOperations := make([]Operation, serverOperationsLen)
+	newID := oper.ID
+	if serverOperationsLen > 0 {
+		newID = composeServerClient(gameOperations, oper.GameID, serverOperations, deltaOperations)
+		opApplied = algebra.ShouldOperationApply(algebraBoard(game), serverOperations, clientOperation)
+	}
+	confirmation.DeltaOperations = deltaOperations
+	confirmation.Operation.GameID = oper.GameID
+	// step 4 => retrieve the current mine proximity value
+	mineProximity, err := api.retrieveRowCol(ctx, tx, user, oper.GameID, oper.Layer, oper.Row, oper.Col)
+	if err != nil {
+		if err == ErrInvalidRowCols {
+			err = response.HTTPError{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}
+		}
+		return err
+	}
+	if !opApplied {
+		// operation should not be applied
+		markOperationNotApplied(confirmation, mineProximity, oper)
+		return nil
+	}
+	if oper.Op == algebra.OpReveal && game.SafeFirstReveal {
+		// step 4b => the first reveal of the game must not hit a mine
+		var moved bool
+		moved, err = api.clearFirstReveal(ctx, tx, game, noGuessBoard, oper.Layer, oper.Row, oper.Col)
+		if err != nil {
+			return err
+		}
+		game.SafeFirstReveal = false
+		if moved {
+			mineProximity, err = api.retrieveRowCol(ctx, tx, user, oper.GameID, oper.Layer, oper.Row, oper.Col)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// step 5a => apply the operation with to the current value
+	newMineProximity, err := clientOperation.Exec(mineProximity)
+	if err != nil {
+		// ErrOperationOutOfBounds
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	// step 5b => if the mine proximity is the same, after the operation, then don't apply the operation.
+	// A chord never changes its own point, it changes its siblings
+	if newMineProximity == mineProximity && oper.Op != algebra.OpChord {
+		// operation had no action, mark as not applied
+		markOperationNotApplied(confirmation, mineProximity, oper)
+		return nil
+	}
+	// the mine proximity is different so the operation changes the actual value.
+	// commit the operation.
+	confirmation.Operation.ID = newID
+	confirmation.Operation.Result = []OperationResult{buildOperationResult(oper, newMineProximity)}
+	err = api.commitOperation(ctx, tx, user, game, confirmation, newMineProximity)
+	if err == errNothingToChord {
+		markOperationNotApplied(confirmation, mineProximity, oper)
+		return err
+	} else if err != nil {
+		return err
+	}
+	confirmation.Operation.Applied = true
+	return nil
+}
+
+func (api api) commitOperation(ctx context.Context, tx *sql.Tx, user security.JWTUser, game *models.Game, confirmation *OperationConfirmation, mineProximity algebra.MineProximity) error {
+	err := api.updateRowCol(ctx, tx, confirmation.Operation.GameID, confirmation.Operation.Layer, confirmation.Operation.Row, confirmation.Operation.Col, mineProximity)
+	if err != nil {
+		api.logger.Printf("error updating game row: %v. Rolling back operation insertion\n", err)
+		return err
+	}
+	newGameOperation := &models.GameOperation{
+		GameID:        confirmation.Operation.GameID,
+		Layer:         int16(confirmation.Operation.Layer),
+		Row:           int16(confirmation.Operation.Row),
+		Col:           int16(confirmation.Operation.Col),
+		PlayerID:      user.ID,
+		MineProximity: int16(mineProximity),
+		OperationID:   confirmation.Operation.ID,
+		Operation:     operationTypeStr(confirmation.Operation.Op),
+	}
+	err = newGameOperation.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		api.logger.Printf("error inserting game operation: %v. Rolling back operation insertion\n", err)
+		return err
+	}
+	startedAt := game.StartedAt
+	if !startedAt.Valid {
+		// the first applied operation starts the clock
+		startedAt = null.TimeFrom(time.Now().UTC())
+		_, err = models.Games(qm.Where("id = ? AND started_at IS NULL", game.ID)).
+			UpdateAll(ctx, tx, models.M{"started_at": startedAt.Time})
+		if err != nil {
+			api.logger.Printf("error starting the game clock: %v. Rolling back operation insertion\n", err)
+			return err
+		}
+	}
+	if game.TurnBased {
+		err = api.passTurn(ctx, tx, game, user)
+		if err == ErrNotYourTurn {
+			return response.HTTPError{
+				Code:    http.StatusConflict,
+				Message: ErrNotYourTurn.Error(),
+			}
+		} else if err != nil {
+			api.logger.Printf("error passing the turn: %v. Rolling back operation insertion\n", err)
+			return err
+		}
+	}
+	if confirmation.Operation.Op == algebra.OpChord {
+		// the chord decides the game status with the points it reveals
+		mineProximity, err = api.chordSiblings(ctx, tx, game, confirmation)
+		if err != nil {
+			if err != errNothingToChord {
+				api.logger.Printf("error revealing chord siblings: %v. Rolling back operation insertion\n", err)
+			}
+			return err
+		}
+	} else if mineProximity == 0 {
+		// the revealed point has no mines around, reveal all the connected empty points and their border
+		err = api.revealEmptyArea(ctx, tx, game, confirmation)
+		if err != nil {
+			api.logger.Printf("error revealing empty area: %v. Rolling back operation insertion\n", err)
+			return err
+		}
+	}
+	if game.Competitive {
+		// the player scores the points revealed by the operation or is eliminated when it hit a mine
+		err = api.scoreOperation(ctx, tx, confirmation, operationScore(confirmation.Operation, mineProximity))
+		if err != nil {
+			api.logger.Printf("error scoring operation: %v. Rolling back operation insertion\n", err)
+			return err
+		}
+	}
+	// check if the game status needs to be updated
+	if mineProximity == algebra.RevealedMine {
+		// a mine only eliminates a player of a competitive game, the game is lost when nobody is left
+		confirmation.Status.Lost = !game.Competitive || winner(confirmation.Status.Scores) == nil
+	}
+	if !confirmation.Status.Lost && mineProximity >= 0 && mineProximity <= algebra.RevealedMine {
 		// if mine proximity is not a mine, then check if the game was won
-		exists, err := models.GameBoardPoints(
-			qm.Where("game_id = ? AND ((mine_proximity <= -1 AND mine_proximity > -10) OR mine_proximity = 9)", confirmation.Operation.GameID),
-		).Exists(ctx, tx)
+		unrevealed := qm.Where("game_id = ? AND ((mine_proximity <= -1 AND mine_proximity > ?) OR mine_proximity = ?)",
+			confirmation.Operation.GameID, algebra.UnrevealedMine, algebra.RevealedMine)
+		if game.Competitive {
+			// the mines hit by the eliminated players stay revealed
+			unrevealed = qm.Where("game_id = ? AND mine_proximity <= -1 AND mine_proximity > ?",
+				confirmation.Operation.GameID, algebra.UnrevealedMine)
+		}
+		exists, err := models.GameBoardPoints(unrevealed).Exists(ctx, tx)
 		if err != nil {
 			api.logger.Printf("error checking if the game was won: %v. Rolling back operation insertion\n", err)
+			return err
+		}
+		if !exists {
+			// no more mines detected, game won
+			confirmation.Status.Won = true
+			if game.Competitive {
+				confirmation.Status.Winner = winner(confirmation.Status.Scores)
+			}
+		}
+	}
+	if confirmation.Status.Won || confirmation.Status.Lost {
+		finishedAt := time.Now().UTC()
+		_, err = api.updateGameState(ctx, tx, confirmation.Operation.GameID, confirmation.Status.Won, false, finishedAt)
+		if err != nil {
+			api.logger.Printf("error setting the game won %v: %v. Rolling back operation insertion\n", confirmation.Status.Won, err)
+			return err
+		}
+		confirmation.Status.Board, err = retrieveFullBoard(ctx, tx, confirmation.Operation.GameID, confirmation.Status.Layers, confirmation.Status.Rows, confirmation.Status.Cols)
+		if err != nil {
+			api.logger.Printf("error getting the whole game board %v: %v. Rolling back operation insertion\n", confirmation.Status.Won, err)
+			return err
+		}
+		confirmation.Status.Elapsed = elapsedMillis(startedAt, null.TimeFrom(finishedAt), game.PausedAt, game.PausedMillis, finishedAt)
+	}
+	game.StartedAt = startedAt
+	return nil
+}
+
+// UndoOperation takes back the last operation of a casual game. The point of the operation is restored to its
+// previous value and an undo operation is recorded instead of deleting the undone one. Undoing the operation
+// that lost a game reopens it.
+func (api api) UndoOperation(ctx context.Context, user security.JWTUser, id int64) (OperationConfirmation, error) {
+	confirmation := OperationConfirmation{}
+	tx, err := api.db.BeginTx(ctx, nil)
+	if err != nil {
+		api.logger.Printf("error beggining transaction for undoing operation: %v\n", err)
+		return confirmation, err
+	}
+	err = api.undoOperation(ctx, tx, user, id, &confirmation)
+	if err != nil {
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back operation with error: %v\n", rollbackError)
+		}
+		return confirmation, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		api.logger.Printf("error commiting undo operation: %v\n", err)
+		return confirmation, err
+	}
+	events.publish(id, confirmationEvent(confirmation))
+	return confirmation, nil
+}
+
+// undoOperation takes back the last operation of a casual game within the transaction. The game stays locked
+// until the transaction ends so no operation can be applied while it is undone.
+func (api api) undoOperation(ctx context.Context, tx *sql.Tx, user security.JWTUser, id int64, confirmation *OperationConfirmation) error {
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+		qm.For("NO KEY UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving game: %v", err)
+		return err
+	}
+	if !game.Casual {
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrGameNotCasual.Error(),
+		}
+	}
+	if game.FinishedAt.Valid && (game.Won.Bool || game.Resigned) {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrGameFinished.Error(),
+		}
+	}
+	if game.PausedAt.Valid {
+		return response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrGamePaused.Error(),
+		}
+	}
+	gameOperations, err := models.GameOperations(
+		qm.Where("game_id = ?", id),
+		qm.OrderBy("operation_id DESC"),
+	).All(ctx, tx)
+	if err != nil {
+		api.logger.Printf("error retrieving game operations: %v\n", err)
+		return err
+	}
+	undone := undoTarget(gameOperations)
+	if undone == nil {
+		return response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrNothingToUndo.Error(),
+		}
+	}
+	undoneType := operationType(undone.Operation)
+	mineProximity, err := algebra.Revert(undoneType, int(undone.MineProximity))
+	// an empty point revealed its area and a chord revealed its siblings, they changed more than one point
+	if err != nil || undone.PlayerID != user.ID || (undoneType == algebra.OpReveal && undone.MineProximity == 0) {
+		return response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrUndoNotAllowed.Error(),
+		}
+	}
+	confirmation.Operation = Operation{
+		ID:      gameOperations[0].OperationID + 1,
+		GameID:  id,
+		Op:      algebra.OpUndo,
+		Layer:   int(undone.Layer),
+		Row:     int(undone.Row),
+		Col:     int(undone.Col),
+		Applied: true,
+	}
+	confirmation.Operation.Result = []OperationResult{buildOperationResult(confirmation.Operation, mineProximity)}
+	confirmation.Status = Status{
+		Layers: int(game.Layers),
+		Rows:   int(game.Rows),
+		Cols:   int(game.Cols),
+	}
+	err = api.updateRowCol(ctx, tx, id, confirmation.Operation.Layer, confirmation.Operation.Row, confirmation.Operation.Col, mineProximity)
+	if err != nil {
+		api.logger.Printf("error updating game row: %v. Rolling back undo operation\n", err)
+		return err
+	}
+	undoOperation := &models.GameOperation{
+		GameID:        id,
+		Layer:         undone.Layer,
+		Row:           undone.Row,
+		Col:           undone.Col,
+		PlayerID:      user.ID,
+		MineProximity: int16(mineProximity),
+		OperationID:   confirmation.Operation.ID,
+		Operation:     models.MineOperationUndo,
+	}
+	err = undoOperation.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		api.logger.Printf("error inserting undo operation: %v. Rolling back undo operation\n", err)
+		return err
+	}
+	gameColumns := models.M{"undos": game.Undos + 1}
+	if game.FinishedAt.Valid {
+		// the undone operation lost the game, the time the game was lost does not count as playing time
+		gameColumns["finished_at"] = nil
+		gameColumns["won"] = false
+		gameColumns["paused_millis"] = game.PausedMillis + durationMillis(time.Now().UTC().Sub(game.FinishedAt.Time))
+	}
+	_, err = models.Games(qm.Where("id = ?", id)).UpdateAll(ctx, tx, gameColumns)
+	if err != nil {
+		api.logger.Printf("error updating game undos: %v. Rolling back undo operation\n", err)
+		return err
+	}
+	return nil
+}
+
+// ResignGame finishes a game that its creator gave up and reveals the whole board
+func (api api) ResignGame(ctx context.Context, user security.JWTUser, id int64) (Status, error) {
+	status := Status{}
+	err := api.checkOwner(ctx, id, user)
+	if err != nil {
+		return status, err
+	}
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+	).One(ctx, api.db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return status, response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving game: %v", err)
+		return status, err
+	}
+	gameFinishedError := response.HTTPError{
+		Code:    http.StatusNotFound,
+		Message: ErrGameFinished.Error(),
+	}
+	if game.FinishedAt.Valid {
+		return status, gameFinishedError
+	}
+	status.Layers = int(game.Layers)
+	status.Rows = int(game.Rows)
+	status.Cols = int(game.Cols)
+	tx, err := api.db.BeginTx(ctx, nil)
+	if err != nil {
+		api.logger.Printf("error beggining transaction for resigning game: %v\n", err)
+		return status, err
+	}
+	finishedAt := time.Now().UTC()
+	affected, err := api.updateGameState(ctx, tx, id, false, true, finishedAt)
+	if err == nil && affected == 0 {
+		// the game was finished by another request
+		err = gameFinishedError
+	}
+	if err == nil && game.PausedAt.Valid {
+		// the game was resigned while paused, the pause is over
+		if game.StartedAt.Valid {
+			game.PausedMillis += durationMillis(finishedAt.Sub(game.PausedAt.Time))
+		}
+		_, err = models.Games(qm.Where("id = ?", id)).
+			UpdateAll(ctx, tx, models.M{"paused_at": nil, "paused_millis": game.PausedMillis})
+	}
+	if err != nil {
+		if err != gameFinishedError {
+			api.logger.Printf("error resigning game %d: %v. Rolling back game resignation\n", id, err)
+		}
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game resignation with error: %v\n", rollbackError)
+		}
+		return status, err
+	}
+	status.Board, err = retrieveFullBoard(ctx, tx, id, status.Layers, status.Rows, status.Cols)
+	if err != nil {
+		api.logger.Printf("error getting the whole game board: %v. Rolling back game resignation\n", err)
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game resignation with error: %v\n", rollbackError)
+		}
+		return status, err
+	}
+	if game.Competitive {
+		status.Scores, err = retrieveScores(ctx, tx, id)
+		if err != nil {
+			api.logger.Printf("error retrieving game scores: %v. Rolling back game resignation\n", err)
 			// just log rollback error
 			rollbackError := tx.Rollback()
 			if rollbackError != nil {
-				api.logger.Printf("error rolling back operation with error: %v\n", rollbackError)
+				api.logger.Printf("error rolling back game resignation with error: %v\n", rollbackError)
+			}
+			return status, err
+		}
+	}
+	status.Resigned = true
+	status.Elapsed = elapsedMillis(game.StartedAt, null.TimeFrom(finishedAt), null.Time{}, game.PausedMillis, finishedAt)
+	err = tx.Commit()
+	if err == nil {
+		events.publish(id, Event{Status: &status})
+	}
+	return status, err
+}
+
+// DeleteGame removes a game with its board and operations. An archived game is kept but it is no longer listed.
+func (api api) DeleteGame(ctx context.Context, user security.JWTUser, id int64, archive bool) error {
+	err := api.checkOwner(ctx, id, user)
+	if err != nil {
+		return err
+	}
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+	).One(ctx, api.db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving game: %v", err)
+		return err
+	}
+	if archive {
+		_, err = models.Games(qm.Where("id = ?", id)).UpdateAll(ctx, api.db, models.M{"archived": true})
+		if err != nil {
+			api.logger.Printf("error archiving game %d: %v\n", id, err)
+		}
+		return err
+	}
+	// the board points and the operations of the game are deleted in cascade
+	_, err = game.Delete(ctx, api.db)
+	if err != nil {
+		api.logger.Printf("error deleting game %d: %v\n", id, err)
+	}
+	return err
+}
+
+// ReplayGame retrieves the mine layout and the ordered operations of a finished game
+func (api api) ReplayGame(ctx context.Context, user security.JWTUser, id int64) (Replay, error) {
+	replay := Replay{}
+	game, err := api.retrieveFinishedGame(ctx, user, id)
+	if err != nil {
+		return replay, err
+	}
+	replay.GameID = game.ID
+	replay.Layers = int(game.Layers)
+	replay.Rows = int(game.Rows)
+	replay.Cols = int(game.Cols)
+	replay.Mines = int(game.Mines)
+	replay.Topology = game.Topology
+	replay.Wrap = game.Wrap
+	replay.Won = game.Won.Bool
+	replay.Resigned = game.Resigned
+	mines, err := models.GameBoardPoints(
+		qm.Select("layer", "row", "col"),
+		qm.Where("game_id = ? AND mine_proximity IN (?, ?, ?, ?)", id, algebra.RevealedMine, algebra.UnrevealedMine, algebra.SuspectMine, algebra.MarkedMine),
+		qm.OrderBy("layer, row, col"),
+	).All(ctx, api.db)
+	if err != nil {
+		api.logger.Printf("error retrieving game %d mines: %v\n", id, err)
+		return replay, err
+	}
+	replay.Layout = make([]LayoutMine, len(mines))
+	for i, m := range mines {
+		replay.Layout[i] = LayoutMine{
+			Layer: int(m.Layer),
+			Row:   int(m.Row),
+			Col:   int(m.Col),
+		}
+	}
+	replay.Operations, err = api.retrieveReplayOperations(ctx, id)
+	return replay, err
+}
+
+// FindEvents retrieves the events of the operations committed on a game after an operation id, in order,
+// followed by the final status when the game has finished. The points changed by an operation are shown as they
+// are now, because only the point of the operation is stored, and hints do not show the point they suggest.
+func (api api) FindEvents(ctx context.Context, user security.JWTUser, id int64, afterID int) ([]Event, error) {
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+	).One(ctx, api.db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving game: %v", err)
+		return nil, err
+	}
+	gameOperations, err := models.GameOperations(
+		qm.Where("game_id = ? AND operation_id > ?", id, afterID),
+		qm.OrderBy("operation_id ASC"),
+	).All(ctx, api.db)
+	if err != nil {
+		api.logger.Printf("error retrieving game operations: %v\n", err)
+		return nil, err
+	}
+	var b *board
+	missed := make([]Event, 0, len(gameOperations)+1)
+	for _, o := range gameOperations {
+		oper := Operation{
+			ID:      o.OperationID,
+			GameID:  id,
+			Op:      operationType(o.Operation),
+			Layer:   int(o.Layer),
+			Row:     int(o.Row),
+			Col:     int(o.Col),
+			Applied: true,
+		}
+		if oper.Op != algebra.OpHint {
+			oper.Result = []OperationResult{buildOperationResult(oper, int(o.MineProximity))}
+		}
+		if oper.Op == algebra.OpChord || (oper.Op == algebra.OpReveal && o.MineProximity == 0) {
+			if b == nil {
+				fullBoard, err := retrieveFullBoard(ctx, api.db, id, int(game.Layers), int(game.Rows), int(game.Cols))
+				if err != nil {
+					api.logger.Printf("error retrieving game board: %v", err)
+					return nil, err
+				}
+				b = gameBoard(game, fullBoard)
+			}
+			for _, p := range b.revealedArea(b.stackedRow(oper.Layer, oper.Row), oper.Col) {
+				oper.Result = append(oper.Result, b.revealedResult(p))
+			}
+		}
+		missed = append(missed, Event{Operation: &oper})
+	}
+	if game.FinishedAt.Valid {
+		status := Status{
+			Layers:   int(game.Layers),
+			Rows:     int(game.Rows),
+			Cols:     int(game.Cols),
+			Won:      game.Won.Bool,
+			Lost:     !game.Won.Bool && !game.Resigned,
+			Resigned: game.Resigned,
+			Elapsed:  elapsedMillis(game.StartedAt, game.FinishedAt, game.PausedAt, game.PausedMillis, game.FinishedAt.Time),
+		}
+		status.Board, err = retrieveFullBoard(ctx, api.db, id, status.Layers, status.Rows, status.Cols)
+		if err != nil {
+			api.logger.Printf("error getting the whole game board: %v", err)
+			return nil, err
+		}
+		if game.Competitive {
+			status.Scores, err = retrieveScores(ctx, api.db, id)
+			if err != nil {
+				api.logger.Printf("error retrieving game scores: %v", err)
+				return nil, err
+			}
+			if status.Won {
+				status.Winner = winner(status.Scores)
+			}
+		}
+		missed = append(missed, Event{Status: &status})
+	}
+	return missed, nil
+}
+
+// ExportGame retrieves a finished game as a game file
+func (api api) ExportGame(ctx context.Context, user security.JWTUser, id int64) (gamefile.File, error) {
+	f := gamefile.New()
+	game, err := api.retrieveFinishedGame(ctx, user, id)
+	if err != nil {
+		return f, err
+	}
+	f.Layers = int(game.Layers)
+	f.Rows = int(game.Rows)
+	f.Cols = int(game.Cols)
+	f.Mines = int(game.Mines)
+	f.Topology = game.Topology
+	f.Wrap = game.Wrap
+	f.Seed = game.Seed
+	f.GeneratorVersion = int(game.GeneratorVersion)
+	f.NoGuess = game.NoGuess
+	f.Won = game.Won.Bool
+	f.Resigned = game.Resigned
+	f.StartedAt = game.StartedAt
+	f.FinishedAt = game.FinishedAt
+	f.Elapsed = elapsedMillis(game.StartedAt, game.FinishedAt, game.PausedAt, game.PausedMillis, game.FinishedAt.Time)
+	f.Board, err = retrieveFullBoard(ctx, api.db, id, f.Layers, f.Rows, f.Cols)
+	if err != nil {
+		api.logger.Printf("error retrieving game board: %v\n", err)
+		return f, err
+	}
+	operations, err := api.retrieveReplayOperations(ctx, id)
+	if err != nil {
+		return f, err
+	}
+	f.Operations = make([]gamefile.Operation, len(operations))
+	for i, o := range operations {
+		f.Operations[i] = gamefile.Operation{
+			ID:            o.ID,
+			Op:            o.Operation,
+			Layer:         o.Layer,
+			Row:           o.Row,
+			Col:           o.Col,
+			MineProximity: o.MineProximity,
+			CreatedAt:     o.CreatedAt,
+			Player:        o.Player.Name,
+		}
+	}
+	return f, nil
+}
+
+// ImportGame recreates a finished game from a game file. The player importing the game becomes its creator and the
+// player of every operation, the players named in the file are never trusted, and neither are the mine proximities,
+// which must match the ones the mines of the board give.
+func (api api) ImportGame(ctx context.Context, user security.JWTUser, f gamefile.File, private bool) (int64, error) {
+	err := f.Validate()
+	if err != nil {
+		return 0, response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	neighbours, ok := boardNeighbours(f.Topology, f.Wrap)
+	if !ok {
+		return 0, response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrUnknownTopology.Error(),
+		}
+	}
+	if f.Layers > 1 {
+		if f.Topology != models.BoardTopologySquare || f.Wrap || f.NoGuess {
+			return 0, response.HTTPError{
+				Code:    http.StatusBadRequest,
+				Message: ErrUnsupportedLayers.Error(),
+			}
+		}
+		neighbours = topology.Cube(f.Layers)
+	}
+	// the board is imported as it is, it does not need to be generated
+	err = validateBoard(f.Layers, f.Rows, f.Cols, f.Mines, GeneratorVersion)
+	if err == nil {
+		err = validateImportedBoard(f, neighbours)
+	}
+	if err != nil {
+		return 0, response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	for _, o := range f.Operations {
+		if o.Op != models.MineOperationReveal && o.Op != models.MineOperationMark && o.Op != models.MineOperationChord &&
+			o.Op != models.MineOperationHint && o.Op != models.MineOperationUndo {
+			return 0, response.HTTPError{
+				Code:    http.StatusBadRequest,
+				Message: algebra.ErrUnknownOperation.Error(),
+			}
+		}
+	}
+	game := &models.Game{
+		CreatorID:        user.ID,
+		Private:          private,
+		Layers:           int16(f.Layers),
+		Rows:             int16(f.Rows),
+		Cols:             int16(f.Cols),
+		Mines:            int16(f.Mines),
+		Topology:         f.Topology,
+		Wrap:             f.Wrap,
+		Seed:             f.Seed,
+		GeneratorVersion: int16(f.GeneratorVersion),
+		NoGuess:          f.NoGuess,
+		Won:              null.BoolFrom(f.Won),
+		Resigned:         f.Resigned,
+		StartedAt:        f.StartedAt,
+		FinishedAt:       f.FinishedAt,
+	}
+	if f.StartedAt.Valid {
+		// the time the game was paused is the time it was not played
+		game.PausedMillis = durationMillis(f.FinishedAt.Time.Sub(f.StartedAt.Time)) - f.Elapsed
+		if game.PausedMillis < 0 {
+			game.PausedMillis = 0
+		}
+	}
+	tx, err := api.db.BeginTx(ctx, nil)
+	if err != nil {
+		api.logger.Printf("error beggining transaction: %v\n", err)
+		return 0, err
+	}
+	err = game.Insert(ctx, tx, boil.Whitelist("private", "cols", "rows", "mines", "creator_id", "safe_first_reveal", "seed", "generator_version", "no_guess", "topology", "wrap", "layers", "won", "resigned", "started_at", "finished_at", "paused_millis"))
+	if err == nil {
+		err = insertBoardPoints(ctx, tx, game, f.Board)
+	}
+	if err == nil {
+		err = insertOwner(ctx, tx, game)
+	}
+	for i := 0; err == nil && i < len(f.Operations); i++ {
+		o := f.Operations[i]
+		gameOperation := &models.GameOperation{
+			GameID:        game.ID,
+			PlayerID:      user.ID,
+			OperationID:   o.ID,
+			Operation:     o.Op,
+			Layer:         int16(o.Layer),
+			Row:           int16(o.Row),
+			Col:           int16(o.Col),
+			MineProximity: int16(o.MineProximity),
+			CreatedAt:     o.CreatedAt,
+		}
+		// the operations keep the time they were made, or none when it is unknown
+		err = gameOperation.Insert(boil.SkipTimestamps(ctx), tx, boil.Infer())
+	}
+	if err != nil {
+		api.logger.Printf("error importing game: %v. Rolling back game import\n", err)
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game import with error: %v\n", rollbackError)
+		}
+		return 0, err
+	}
+	return game.ID, tx.Commit()
+}
+
+// validateImportedBoard rebuilds the mine proximities of a game file from its mines and checks that every point of
+// its board and the result of every operation that changed a single point match them
+func validateImportedBoard(f gamefile.File, neighbours topology.Neighbours) error {
+	layout := make([]LayoutMine, 0, f.Mines)
+	for r, row := range f.Board {
+		for c, p := range row {
+			if p == algebra.RevealedMine || isMine(p) {
+				layout = append(layout, LayoutMine{Layer: r / f.Rows, Row: r % f.Rows, Col: c})
+			}
+		}
+	}
+	rebuilt, err := NewLayoutBoard(f.Layers, f.Rows, f.Cols, layout, neighbours)
+	if err != nil {
+		return err
+	}
+	for r, row := range f.Board {
+		for c, p := range row {
+			if hiddenMineProximity(p) != rebuilt[r][c] {
+				return ErrMineProximityMismatch
 			}
-			return err
 		}
-		if !exists {
-			// no more mines detected, game won
-			confirmation.Status.Won = true
+	}
+	for _, o := range f.Operations {
+		if o.Op == models.MineOperationHint || (o.Op == models.MineOperationChord && o.MineProximity == algebra.RevealedMine) {
+			// a hint does not record the point and a chord records the mine one of the siblings revealed
+			continue
+		}
+		p := o.MineProximity
+		if p < algebra.MarkedMine || p > algebra.RevealedMine || hiddenMineProximity(p) != rebuilt[o.Layer*f.Rows+o.Row][o.Col] {
+			return ErrMineProximityMismatch
 		}
 	}
-	if confirmation.Status.Won || confirmation.Status.Lost {
-		_, err = api.updateGameState(ctx, tx, confirmation.Operation.GameID, confirmation.Status.Won)
-		if err != nil {
-			api.logger.Printf("error setting the game won %v: %v. Rolling back operation insertion\n", confirmation.Status.Won, err)
-			// just log rollback error
-			rollbackError := tx.Rollback()
-			if rollbackError != nil {
-				api.logger.Printf("error rolling back operation with error: %v\n", rollbackError)
+	return nil
+}
+
+// retrieveFinishedGame retrieves a finished game that the player can see
+func (api api) retrieveFinishedGame(ctx context.Context, user security.JWTUser, id int64) (*models.Game, error) {
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+	).One(ctx, api.db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
 			}
-			return err
 		}
-		confirmation.Status.Board, err = retrieveFullBoard(ctx, tx, confirmation.Operation.GameID, confirmation.Status.Rows, confirmation.Status.Cols)
+		api.logger.Printf("error retrieving game: %v", err)
+		return nil, err
+	}
+	if !game.FinishedAt.Valid {
+		return nil, response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrGameNotFinished.Error(),
+		}
+	}
+	return game, nil
+}
+
+// retrieveReplayOperations retrieves every operation of a game ordered by operation id
+func (api api) retrieveReplayOperations(ctx context.Context, id int64) ([]ReplayOperation, error) {
+	operations := []ReplayOperation{}
+	err := queries.Raw(`
+		SELECT o.operation_id as "operation_id", o.operation as "operation",
+		o.layer as "layer", o.row as "row", o.col as "col",
+		o.mine_proximity as "mine_proximity", o.created_at as "created_at",
+		p.id as "players.id", p.name as "players.name"
+		FROM game_operations o INNER JOIN players p on o.player_id = p.id
+		WHERE o.game_id = $1
+		ORDER BY o.operation_id ASC`, id,
+	).Bind(ctx, api.db, &operations)
+	if err != nil && err != sql.ErrNoRows {
+		api.logger.Printf("error retrieving game %d operations: %v\n", id, err)
+		return operations, err
+	}
+	for i := range operations {
+		o := &operations[i]
+		o.Op = operationType(o.Operation)
+		o.Result = buildOperationResult(Operation{Layer: o.Layer, Row: o.Row, Col: o.Col}, o.MineProximity)
+	}
+	return operations, nil
+}
+
+// undoTarget returns the last operation that has not been undone yet from operations ordered from the newest
+// to the oldest. Every undo operation takes back the newest operation that changed the board before it.
+func undoTarget(gameOperations models.GameOperationSlice) *models.GameOperation {
+	undos := 0
+	for _, o := range gameOperations {
+		if o.Operation == models.MineOperationHint {
+			continue
+		}
+		if o.Operation == models.MineOperationUndo {
+			undos++
+		} else if undos > 0 {
+			undos--
+		} else {
+			return o
+		}
+	}
+	return nil
+}
+
+// revealEmptyArea reveals every point connected to the operation point through points without mines around them
+func (api api) revealEmptyArea(ctx context.Context, tx *sql.Tx, game *models.Game, confirmation *OperationConfirmation) error {
+	gameID := confirmation.Operation.GameID
+	boardPoints, err := retrieveFullBoard(ctx, tx, gameID, confirmation.Status.Layers, confirmation.Status.Rows, confirmation.Status.Cols)
+	if err != nil {
+		return err
+	}
+	b := gameBoard(game, boardPoints)
+	revealed := b.revealEmptyArea(b.stackedRow(confirmation.Operation.Layer, confirmation.Operation.Row), confirmation.Operation.Col)
+	if len(revealed) == 0 {
+		return nil
+	}
+	err = updateBoardPoints(ctx, tx, gameID, b, revealed)
+	if err != nil {
+		return err
+	}
+	for _, p := range revealed {
+		confirmation.Operation.Result = append(confirmation.Operation.Result, b.revealedResult(p))
+	}
+	return nil
+}
+
+// clearFirstReveal moves the mines found around the first revealed point of a game to other random points, or to
+// the points of the no guess board of a no guess game. Only the first reveal of the game clears the area, it
+// returns true if any mine was moved.
+func (api api) clearFirstReveal(ctx context.Context, tx *sql.Tx, game *models.Game, noGuessBoard *board, layer, row, col int) (bool, error) {
+	// only one reveal can claim the first reveal of the game
+	aff, err := models.Games(qm.Where("id = ? AND safe_first_reveal = true", game.ID)).
+		UpdateAll(ctx, tx, models.M{"safe_first_reveal": false})
+	if err != nil || aff == 0 {
 		if err != nil {
-			api.logger.Printf("error getting the whole game board %v: %v. Rolling back operation insertion\n", confirmation.Status.Won, err)
-			// just log rollback error
-			rollbackError := tx.Rollback()
-			if rollbackError != nil {
-				api.logger.Printf("error rolling back operation with error: %v\n", rollbackError)
+			api.logger.Printf("error claiming the first reveal: %v. Rolling back\n", err)
+		}
+		return false, err
+	}
+	boardPoints, err := retrieveFullBoard(ctx, tx, game.ID, int(game.Layers), int(game.Rows), int(game.Cols))
+	if err != nil {
+		api.logger.Printf("error retrieving the board for the first reveal: %v. Rolling back\n", err)
+		return false, err
+	}
+	b := gameBoard(game, boardPoints)
+	row = b.stackedRow(layer, row)
+	var changed []boardPoint
+	if game.NoGuess {
+		if noGuessBoard == nil {
+			// no board was looked for before locking the game
+			return false, response.HTTPError{
+				Code:    http.StatusConflict,
+				Message: ErrNoGuessFirstReveal.Error(),
 			}
-			return err
 		}
-	} else if mineProximity == 0 {
-		// TODO: if the game was not lost nor one but the mine proximity was 0, reveal the sibling places with zero mines
-		api.logger.Print("Missing implementation")
+		changed = b.replaceMines(noGuessBoard)
+	} else {
+		// the game seed makes the first reveal reproducible as well
+		changed = b.moveMinesAway(row, col, rand.New(rand.NewSource(game.Seed)))
+	}
+	if len(changed) > 0 {
+		err = updateBoardPoints(ctx, tx, game.ID, b, changed)
+		if err != nil {
+			api.logger.Printf("error moving mines away from the first reveal: %v. Rolling back\n", err)
+			return false, err
+		}
+	}
+	return len(changed) > 0, nil
+}
+
+// chordSiblings reveals the unmarked siblings of the operation point if the siblings marked as mines match its
+// mine proximity. It returns a revealed mine if a mine was revealed or the mine proximity of the operation point
+// otherwise.
+func (api api) chordSiblings(ctx context.Context, tx *sql.Tx, game *models.Game, confirmation *OperationConfirmation) (algebra.MineProximity, error) {
+	gameID := confirmation.Operation.GameID
+	boardPoints, err := retrieveFullBoard(ctx, tx, gameID, confirmation.Status.Layers, confirmation.Status.Rows, confirmation.Status.Cols)
+	if err != nil {
+		return 0, err
+	}
+	b := gameBoard(game, boardPoints)
+	row := b.stackedRow(confirmation.Operation.Layer, confirmation.Operation.Row)
+	col := confirmation.Operation.Col
+	revealed := b.chord(row, col)
+	if len(revealed) == 0 {
+		return 0, errNothingToChord
+	}
+	err = updateBoardPoints(ctx, tx, gameID, b, revealed)
+	if err != nil {
+		return 0, err
+	}
+	mineProximity := b.board[row][col]
+	for _, p := range revealed {
+		if b.board[p.row][p.col] == algebra.RevealedMine {
+			mineProximity = algebra.RevealedMine
+		}
+		confirmation.Operation.Result = append(confirmation.Operation.Result, b.revealedResult(p))
+	}
+	return mineProximity, nil
+}
+
+func (api api) updateGameState(ctx context.Context, tx *sql.Tx, gameID int64, won, resigned bool, finishedAt time.Time) (int64, error) {
+	return models.Games(qm.Where("id = ? AND finished_at IS NULL", gameID)).
+		UpdateAll(ctx, tx, models.M{"won": won, "resigned": resigned, "finished_at": finishedAt})
+}
+
+// elapsedMillis is the playing time of a game in milliseconds. The clock stops when the game finishes or is
+// paused and the time spent on previous pauses is not counted.
+func elapsedMillis(startedAt, finishedAt, pausedAt null.Time, pausedMillis int64, now time.Time) int64 {
+	if !startedAt.Valid {
+		return 0
+	}
+	end := now
+	if finishedAt.Valid {
+		end = finishedAt.Time
+	} else if pausedAt.Valid {
+		end = pausedAt.Time
+	}
+	elapsed := durationMillis(end.Sub(startedAt.Time)) - pausedMillis
+	if elapsed < 0 {
+		return 0
 	}
-	return tx.Commit()
+	return elapsed
 }
 
-func (api api) updateGameState(ctx context.Context, tx *sql.Tx, gameID int64, won bool) (int64, error) {
-	return models.Games(qm.Where("id = ?", gameID)).
-		UpdateAll(ctx, tx, models.M{"won": won, "finished_at": time.Now().UTC().Format(time.RFC3339)})
+func durationMillis(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
 }
 
 // storeGameBoard stores a Game board in the game_board_points table
@@ -488,8 +2074,18 @@ func (api api) storeGameBoard(ctx context.Context, user security.JWTUser, game *
 		api.logger.Printf("error beggining transaction: %v\n", err)
 		return err
 	}
+	if game.Topology == "" {
+		game.Topology = models.BoardTopologySquare
+	}
+	if game.Layers == 0 {
+		game.Layers = 1
+	}
+	if game.TurnBased && !game.TurnStartedAt.Valid {
+		// the creator has the first turn
+		game.TurnStartedAt = null.TimeFrom(time.Now().UTC())
+	}
 	// do not insert map
-	err = game.Insert(ctx, tx, boil.Whitelist("private", "cols", "rows", "mines", "creator_id"))
+	err = game.Insert(ctx, tx, boil.Whitelist("private", "cols", "rows", "mines", "creator_id", "safe_first_reveal", "seed", "generator_version", "no_guess", "topology", "wrap", "layers", "preset", "casual", "spectate_only", "competitive", "turn_based", "turn_seconds", "turn_started_at"))
 	if err != nil {
 		api.logger.Printf("error inserting game: %v. Rolling back game insertion\n", err)
 		// just log rollback error
@@ -499,24 +2095,19 @@ func (api api) storeGameBoard(ctx context.Context, user security.JWTUser, game *
 		}
 		return err
 	}
-	var bigInsert strings.Builder
-	fmt.Fprintf(&bigInsert, "INSERT INTO game_board_points (game_id, row, col, mine_proximity, created_at) VALUES ")
-	first := true
-	creationDateStr := time.Now().UTC().Format(time.RFC3339)
-	for row := range board {
-		for col := range board[row] {
-			if first {
-				first = false
-			} else {
-				bigInsert.WriteString(",")
-			}
-			fmt.Fprintf(&bigInsert, "(%d, %d, %d, %d, '%s')", game.ID, row, col, board[row][col], creationDateStr)
+	err = insertBoardPoints(ctx, tx, game, board)
+	if err != nil {
+		api.logger.Printf("error inserting all game board points for game %d: %s\n", game.ID, err)
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game creation with error: %v\n", rollbackError)
 		}
+		return err
 	}
-	bigInsert.WriteString(";")
-	_, err = queries.Raw(bigInsert.String()).ExecContext(ctx, tx)
+	err = insertOwner(ctx, tx, game)
 	if err != nil {
-		api.logger.Printf("error inserting all game board points for game %d: %s\n", game.ID, err)
+		api.logger.Printf("error inserting the owner of game %d: %s\n", game.ID, err)
 		// just log rollback error
 		rollbackError := tx.Rollback()
 		if rollbackError != nil {
@@ -524,6 +2115,22 @@ func (api api) storeGameBoard(ctx context.Context, user security.JWTUser, game *
 		}
 		return err
 	}
+	if game.TurnBased {
+		creatorTurn := &models.GameTurn{
+			GameID:   game.ID,
+			PlayerID: game.CreatorID,
+		}
+		err = creatorTurn.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			api.logger.Printf("error inserting the creator turn for game %d: %s\n", game.ID, err)
+			// just log rollback error
+			rollbackError := tx.Rollback()
+			if rollbackError != nil {
+				api.logger.Printf("error rolling back game creation with error: %v\n", rollbackError)
+			}
+			return err
+		}
+	}
 	err = tx.Commit()
 	if err != nil {
 		api.logger.Printf("error commiting transaction: %v. Rolling back operation\n", err)
@@ -537,12 +2144,18 @@ func (api api) storeGameBoard(ctx context.Context, user security.JWTUser, game *
 	return nil
 }
 
-func (api api) retrieveRowCol(ctx context.Context, user security.JWTUser, gameID int64, row, col int) (int, error) {
+// lockGame retrieves a game and locks it until the transaction ends. Every change to the operations of a game
+// locks it first, so the players wait for the operations ahead of theirs in the order they arrived.
+func lockGame(ctx context.Context, tx *sql.Tx, id int64) (*models.Game, error) {
+	return models.Games(qm.Where("id = ?", id), qm.For("NO KEY UPDATE")).One(ctx, tx)
+}
+
+func (api api) retrieveRowCol(ctx context.Context, executor boil.ContextExecutor, user security.JWTUser, gameID int64, layer, row, col int) (int, error) {
 	gameBoardPoint, err := models.GameBoardPoints(
 		qm.Select("mine_proximity"),
-		qm.InnerJoin("games g on g.id = game_board_points.game_id"),
-		qm.Where("game_board_points.game_id = ? AND game_board_points.row = ? AND game_board_points.col = ? AND (g.creator_id = ? OR g.private = false)", gameID, row, col, user.ID),
-	).One(ctx, api.db)
+		qm.InnerJoin("games on games.id = game_board_points.game_id"),
+		qm.Where("game_board_points.game_id = ? AND game_board_points.layer = ? AND game_board_points.row = ? AND game_board_points.col = ? AND "+accessibleGame, gameID, layer, row, col, user.ID),
+	).One(ctx, executor)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, ErrInvalidRowCols
@@ -553,10 +2166,10 @@ func (api api) retrieveRowCol(ctx context.Context, user security.JWTUser, gameID
 	return int(gameBoardPoint.MineProximity), nil
 }
 
-func (api api) updateRowCol(ctx context.Context, gameID int64, row, col, mineProximity int) error {
+func (api api) updateRowCol(ctx context.Context, executor boil.ContextExecutor, gameID int64, layer, row, col, mineProximity int) error {
 	aff, err := models.GameBoardPoints(
-		qm.Where("game_id = ? AND row = ? AND col = ?", gameID, row, col),
-	).UpdateAll(ctx, api.db, models.M{
+		qm.Where("game_id = ? AND layer = ? AND row = ? AND col = ?", gameID, layer, row, col),
+	).UpdateAll(ctx, executor, models.M{
 		"mine_proximity": mineProximity,
 	})
 	if err != nil {
@@ -568,26 +2181,90 @@ func (api api) updateRowCol(ctx context.Context, gameID int64, row, col, minePro
 	return nil
 }
 
-// NewBoard creates a random minesweeper board
+// updateBoardPoints stores the mine proximity of several points of a board in a single statement
+func updateBoardPoints(ctx context.Context, executor boil.ContextExecutor, gameID int64, b *board, points []boardPoint) error {
+	var bigUpdate strings.Builder
+	fmt.Fprintf(&bigUpdate, "UPDATE game_board_points AS p SET mine_proximity = v.mine_proximity FROM (VALUES ")
+	for i, p := range points {
+		if i > 0 {
+			bigUpdate.WriteString(",")
+		}
+		layer, row := b.layerPoint(p.row)
+		fmt.Fprintf(&bigUpdate, "(%d, %d, %d, %d)", layer, row, p.col, b.board[p.row][p.col])
+	}
+	fmt.Fprintf(&bigUpdate, ") AS v(layer, row, col, mine_proximity) WHERE p.game_id = %d AND p.layer = v.layer AND p.row = v.row AND p.col = v.col;", gameID)
+	res, err := queries.Raw(bigUpdate.String()).ExecContext(ctx, executor)
+	if err != nil {
+		return err
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if aff != int64(len(points)) {
+		return fmt.Errorf("invalid row count %d when updating %d game mine proximities", aff, len(points))
+	}
+	return nil
+}
+
+// NewBoard creates a random square minesweeper board
 func NewBoard(rows, cols, mines int) ([][]int, error) {
+	return NewSeededBoard(rows, cols, mines, time.Now().UTC().UnixNano(), GeneratorVersion, topology.Square)
+}
+
+// NewSeededBoard creates a minesweeper board that is always the same for the same seed, size, mines and
+// generator version
+func NewSeededBoard(rows, cols, mines int, seed int64, generatorVersion int, neighbours topology.Neighbours) ([][]int, error) {
 	var initializedBoard [][]int
-	if rows <= 0 || cols <= 0 {
-		return initializedBoard, ErrInvalidRowCols
+	err := validateBoard(1, rows, cols, mines, generatorVersion)
+	if err != nil {
+		return initializedBoard, err
 	}
-	if rows > 100 || cols > 100 {
-		return initializedBoard, ErrInvalidRowCols
+	return newSeededBoard(rows, cols, mines, seed, neighbours).board, nil
+}
+
+// NewLayeredBoard creates a minesweeper board with several square layers that is always the same for the same
+// seed, size, mines and generator version. The rows of every layer are stacked one after the other.
+func NewLayeredBoard(layers, rows, cols, mines int, seed int64, generatorVersion int) ([][]int, error) {
+	var initializedBoard [][]int
+	err := validateBoard(layers, rows, cols, mines, generatorVersion)
+	if err != nil {
+		return initializedBoard, err
 	}
-	if mines >= (rows * cols) {
-		return initializedBoard, ErrTooManyMines
+	b := newSeededBoard(layers*rows, cols, mines, seed, topology.Cube(layers))
+	return b.board, nil
+}
+
+// NewLayoutBoard creates a minesweeper board with the mines in the points of a layout. The rows of every layer
+// are stacked one after the other.
+func NewLayoutBoard(layers, rows, cols int, layout []LayoutMine, neighbours topology.Neighbours) ([][]int, error) {
+	var initializedBoard [][]int
+	err := validateBoard(layers, rows, cols, len(layout), GeneratorVersion)
+	if err != nil {
+		return initializedBoard, err
 	}
-	if mines <= 0 {
-		return initializedBoard, ErrNoneMines
+	b := newEmptyBoard(layers*rows, cols, len(layout), neighbours)
+	b.layers = layers
+	for _, m := range layout {
+		if m.Layer < 0 || m.Layer >= layers || m.Row < 0 || m.Row >= rows || m.Col < 0 || m.Col >= cols {
+			return initializedBoard, ErrInvalidLayout
+		}
+		row := b.stackedRow(m.Layer, m.Row)
+		if b.board[row][m.Col] == algebra.UnrevealedMine {
+			return initializedBoard, ErrInvalidLayout
+		}
+		b.placeMine(row, m.Col)
 	}
+	return b.board, nil
+}
+
+func newSeededBoard(rows, cols, mines int, seed int64, neighbours topology.Neighbours) *board {
 	b := &board{
-		rows:  rows,
-		cols:  cols,
-		mines: mines,
-		board: make([][]int, rows),
+		rows:       rows,
+		cols:       cols,
+		mines:      mines,
+		board:      make([][]int, rows),
+		neighbours: neighbours,
 	}
 
 	boardCartesian := make([]boardPoint, rows*cols)
@@ -603,7 +2280,7 @@ func NewBoard(rows, cols, mines int) ([][]int, error) {
 			}
 		}
 	}
-	random := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	random := rand.New(rand.NewSource(seed))
 	for mines > 0 {
 		mineIndex := random.Intn(len(boardCartesian) - 1)
 		p := boardCartesian[mineIndex]
@@ -611,55 +2288,310 @@ func NewBoard(rows, cols, mines int) ([][]int, error) {
 		boardCartesian = append(boardCartesian[:mineIndex], boardCartesian[mineIndex+1:]...)
 		mines--
 	}
-	return b.board, nil
+	return b
+}
+
+func validateBoard(layers, rows, cols, mines, generatorVersion int) error {
+	if generatorVersion != GeneratorVersion {
+		return ErrUnknownGeneratorVersion
+	}
+	if layers <= 0 || layers > maxLayers {
+		return ErrInvalidLayers
+	}
+	if rows <= 0 || cols <= 0 {
+		return ErrInvalidRowCols
+	}
+	if rows > 100 || cols > 100 {
+		return ErrInvalidRowCols
+	}
+	if mines >= (layers * rows * cols) {
+		return ErrTooManyMines
+	}
+	if mines > math.MaxInt16 {
+		// the mines column is a smallint
+		return ErrTooManyMines
+	}
+	if mines <= 0 {
+		return ErrNoneMines
+	}
+	return nil
+}
+
+func newEmptyBoard(rows, cols, mines int, neighbours topology.Neighbours) *board {
+	b := &board{
+		rows:       rows,
+		cols:       cols,
+		mines:      mines,
+		board:      make([][]int, rows),
+		neighbours: neighbours,
+	}
+	for r := range b.board {
+		b.board[r] = make([]int, cols)
+		for c := range b.board[r] {
+			b.board[r][c] = -1
+		}
+	}
+	return b
 }
 
 func (b *board) placeMine(row, col int) {
-	b.board[row][col] = -10
+	b.board[row][col] = algebra.UnrevealedMine
 	for _, s := range b.siblingPoints(row, col) {
-		if b.board[s.row][s.col] > -9 {
+		if b.board[s.row][s.col] > algebra.UnrevealedMine {
 			b.board[s.row][s.col] = b.board[s.row][s.col] - 1
 		}
 	}
 }
 
-func (b *board) siblingPoints(row, col int) []boardPoint {
-	rowPlaces := make([]int, 0, 3)
-	colPlaces := make([]int, 0, 3)
-	points := make([]boardPoint, 0, 8)
-	if row > 0 {
-		rowPlaces = append(rowPlaces, row-1)
+// moveMinesAway moves the mines found in a point and its siblings to random points outside of that area and
+// recalculates the mine proximity of the whole board, keeping the marks. If the board is too dense then only
+// the mines that fit outside of the area are moved and the point itself is cleared using a free sibling.
+// It returns the changed points.
+func (b *board) moveMinesAway(row, col int, random *rand.Rand) []boardPoint {
+	area := append([]boardPoint{{row: row, col: col}}, b.siblingPoints(row, col)...)
+	inArea := make(map[boardPoint]bool, len(area))
+	for _, p := range area {
+		inArea[p] = true
+	}
+	mines := make(map[boardPoint]bool, b.mines)
+	candidates := make([]boardPoint, 0, b.rows*b.cols)
+	for r := range b.board {
+		for c := range b.board[r] {
+			p := boardPoint{row: r, col: c}
+			if isMine(b.board[r][c]) {
+				mines[p] = true
+			} else if !inArea[p] {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+	for _, p := range area {
+		if !mines[p] {
+			continue
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		i := random.Intn(len(candidates))
+		delete(mines, p)
+		mines[candidates[i]] = true
+		candidates = append(candidates[:i], candidates[i+1:]...)
+	}
+	origin := boardPoint{row: row, col: col}
+	if mines[origin] {
+		// there is no room outside of the area, move the mine to a free sibling
+		for _, p := range area[1:] {
+			if !mines[p] {
+				delete(mines, origin)
+				mines[p] = true
+				break
+			}
+		}
+	}
+	relocated := newEmptyBoard(b.rows, b.cols, b.mines, b.neighbours)
+	for p := range mines {
+		relocated.placeMine(p.row, p.col)
+	}
+	return b.replaceMines(relocated)
+}
+
+// replaceMines replaces the unrevealed mine proximities with the ones of another board with the same size,
+// keeping the marks. It returns the changed points.
+func (b *board) replaceMines(other *board) []boardPoint {
+	changed := make([]boardPoint, 0)
+	for r := range b.board {
+		for c := range b.board[r] {
+			mp := other.board[r][c] + markOffset(b.board[r][c])
+			if mp != b.board[r][c] {
+				b.board[r][c] = mp
+				changed = append(changed, boardPoint{row: r, col: c})
+			}
+		}
+	}
+	return changed
+}
+
+// chord reveals all the unmarked siblings of a revealed point when the amount of siblings marked as mines matches
+// its mine proximity, revealing the empty areas found. Points marked as suspicious are left untouched.
+// It returns the points that were revealed.
+func (b *board) chord(row, col int) []boardPoint {
+	revealed := make([]boardPoint, 0)
+	mp := b.board[row][col]
+	if mp < 0 || mp > algebra.MaxMineProximity {
+		return revealed
+	}
+	siblings := b.siblingPoints(row, col)
+	marked := 0
+	for _, s := range siblings {
+		if proximityToState(b.board[s.row][s.col]) == StateMarkedMine {
+			marked++
+		}
 	}
-	rowPlaces = append(rowPlaces, row)
-	if row < b.rows-1 {
-		rowPlaces = append(rowPlaces, row+1)
+	if marked != mp {
+		return revealed
 	}
-	if col > 0 {
-		colPlaces = append(colPlaces, col-1)
+	for _, s := range siblings {
+		smp := b.board[s.row][s.col]
+		// only unrevealed and unmarked points are revealed, mines included
+		if smp <= -1 && smp >= algebra.UnrevealedMine {
+			b.board[s.row][s.col] = -smp - 1
+			revealed = append(revealed, s)
+			if b.board[s.row][s.col] == 0 {
+				revealed = append(revealed, b.revealEmptyArea(s.row, s.col)...)
+			}
+		}
 	}
-	colPlaces = append(colPlaces, col)
-	if col < b.cols-1 {
-		colPlaces = append(colPlaces, col+1)
+	return revealed
+}
+
+// revealedArea returns the points that an empty point or a chord on a point have revealed, as they are now: the
+// revealed siblings of the point and every revealed point connected to them through other empty points.
+func (b *board) revealedArea(row, col int) []boardPoint {
+	area := make([]boardPoint, 0)
+	visited := map[boardPoint]bool{{row: row, col: col}: true}
+	pending := b.siblingPoints(row, col)
+	for len(pending) > 0 {
+		p := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if visited[p] {
+			continue
+		}
+		visited[p] = true
+		mp := b.board[p.row][p.col]
+		if mp < 0 || mp > algebra.RevealedMine {
+			continue
+		}
+		area = append(area, p)
+		if mp == 0 {
+			pending = append(pending, b.siblingPoints(p.row, p.col)...)
+		}
 	}
-	for i := range rowPlaces {
-		for j := range colPlaces {
-			if rowPlaces[i] != row || colPlaces[j] != col {
-				points = append(points, boardPoint{
-					row: rowPlaces[i],
-					col: colPlaces[j],
-				})
+	return area
+}
+
+// revealEmptyArea reveals all the points connected to an empty point through other empty points, including
+// the numbered points in the border. Marked points are left untouched. It returns the points that were revealed.
+func (b *board) revealEmptyArea(row, col int) []boardPoint {
+	revealed := make([]boardPoint, 0)
+	pending := []boardPoint{{row: row, col: col}}
+	for len(pending) > 0 {
+		p := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for _, s := range b.siblingPoints(p.row, p.col) {
+			mp := b.board[s.row][s.col]
+			// only unrevealed points without a mine can be revealed
+			if mp <= -1 && mp > algebra.UnrevealedMine {
+				b.board[s.row][s.col] = -mp - 1
+				revealed = append(revealed, s)
+				if b.board[s.row][s.col] == 0 {
+					pending = append(pending, s)
+				}
 			}
 		}
 	}
+	return revealed
+}
+
+func (b *board) siblingPoints(row, col int) []boardPoint {
+	var buf [algebra.MaxMineProximity]topology.Point
+	siblings := b.neighbourFunc()(b.rows, b.cols, row, col, buf[:0])
+	points := make([]boardPoint, len(siblings))
+	for i, p := range siblings {
+		points[i] = boardPoint{
+			row: p.Row,
+			col: p.Col,
+		}
+	}
 	return points
 }
 
+// boardNeighbours returns the neighbours function of a board topology and whether the topology exists
+func boardNeighbours(boardTopology string, wrap bool) (topology.Neighbours, bool) {
+	if wrap {
+		neighbours, ok := wrappedTopologies[boardTopology]
+		return neighbours, ok
+	}
+	neighbours, ok := topologies[boardTopology]
+	return neighbours, ok
+}
+
+// gameNeighbours returns the neighbours function of a stored game
+func gameNeighbours(game *models.Game) topology.Neighbours {
+	if game.Layers > 1 {
+		return topology.Cube(int(game.Layers))
+	}
+	neighbours, _ := boardNeighbours(game.Topology, game.Wrap)
+	return neighbours
+}
+
+// algebraBoard returns the board of a game where the operations are composed
+func algebraBoard(game *models.Game) algebra.Board {
+	return algebra.Board{
+		Layers:     int(game.Layers),
+		Rows:       int(game.Rows),
+		Cols:       int(game.Cols),
+		Neighbours: gameNeighbours(game),
+	}
+}
+
+// gameBoard returns the board of a stored game with the given points
+func gameBoard(game *models.Game, points [][]int) *board {
+	return &board{
+		rows:       len(points),
+		cols:       int(game.Cols),
+		mines:      int(game.Mines),
+		layers:     int(game.Layers),
+		board:      points,
+		neighbours: gameNeighbours(game),
+	}
+}
+
+// stackedRow returns the row of the board where the given row of a layer is stacked
+func (b *board) stackedRow(layer, row int) int {
+	return layer*(b.rows/b.layerCount()) + row
+}
+
+// layerPoint returns the layer and the row in that layer of a row of the board
+func (b *board) layerPoint(row int) (int, int) {
+	layerRows := b.rows / b.layerCount()
+	return row / layerRows, row % layerRows
+}
+
+// layerCount returns the amount of layers of the board, boards without layers are flat
+func (b *board) layerCount() int {
+	if b.layers < 1 {
+		return 1
+	}
+	return b.layers
+}
+
+// revealedResult returns the operation result of a point revealed by the board
+func (b *board) revealedResult(p boardPoint) OperationResult {
+	layer, row := b.layerPoint(p.row)
+	return OperationResult{
+		Layer:         layer,
+		Row:           row,
+		Col:           p.col,
+		MineProximity: b.board[p.row][p.col],
+		PointState:    StateRevealed,
+	}
+}
+
+// neighbourFunc returns the neighbours function of the board topology, boards without one are square
+func (b *board) neighbourFunc() topology.Neighbours {
+	if b.neighbours == nil {
+		return topology.Square
+	}
+	return b.neighbours
+}
+
 func composeServerClient(gameOperations models.GameOperationSlice, gameID int64, serverOperations []algebra.Operation, deltaOperations []Operation) int {
 	newID := 0
 	for i, o := range gameOperations {
 		// since this operations comes from the server, it will never throw an error
 		// because we guarantee it is valid
 		opRes := OperationResult{
+			Layer:      int(o.Layer),
 			Row:        int(o.Row),
 			Col:        int(o.Col),
 			PointState: proximityToState(int(o.MineProximity)),
@@ -672,6 +2604,7 @@ func composeServerClient(gameOperations models.GameOperationSlice, gameID int64,
 		deltaOperations[i] = Operation{
 			ID:      o.OperationID,
 			GameID:  gameID,
+			Layer:   int(o.Layer),
 			Row:     int(o.Row),
 			Col:     int(o.Col),
 			Op:      operationType(o.Operation),
@@ -684,38 +2617,61 @@ func composeServerClient(gameOperations models.GameOperationSlice, gameID int64,
 	return newID
 }
 
-func retrieveFullBoard(ctx context.Context, executor boil.ContextExecutor, gameID int64, rows, cols int) ([][]int, error) {
-	return retrieveBoard(ctx, executor, gameID, rows, cols, pAll)
+// insertBoardPoints inserts every point of the board of a game in a single statement
+func insertBoardPoints(ctx context.Context, executor boil.ContextExecutor, game *models.Game, board [][]int) error {
+	var bigInsert strings.Builder
+	fmt.Fprintf(&bigInsert, "INSERT INTO game_board_points (game_id, layer, row, col, mine_proximity, created_at) VALUES ")
+	first := true
+	creationDateStr := time.Now().UTC().Format(time.RFC3339)
+	for row := range board {
+		for col := range board[row] {
+			if first {
+				first = false
+			} else {
+				bigInsert.WriteString(",")
+			}
+			// the rows of every layer are stacked one after the other
+			fmt.Fprintf(&bigInsert, "(%d, %d, %d, %d, %d, '%s')", game.ID, row/int(game.Rows), row%int(game.Rows), col, board[row][col], creationDateStr)
+		}
+	}
+	bigInsert.WriteString(";")
+	_, err := queries.Raw(bigInsert.String()).ExecContext(ctx, executor)
+	return err
+}
+
+// retrieveFullBoard retrieves every point of a board, the rows of every layer are stacked one after the other
+func retrieveFullBoard(ctx context.Context, executor boil.ContextExecutor, gameID int64, layers, rows, cols int) ([][]int, error) {
+	return retrieveBoard(ctx, executor, gameID, layers, rows, cols, pAll)
 }
 
-func retrieveNullableBoard(ctx context.Context, executor boil.ContextExecutor, gameID int64, rows, cols int, mask proximityMask) ([][]null.Int, error) {
+func retrieveNullableBoard(ctx context.Context, executor boil.ContextExecutor, gameID int64, layers, rows, cols int, mask proximityMask) ([][]null.Int, error) {
 	var board [][]null.Int
 	points, err := retrieveMines(ctx, executor, gameID, mask)
 	if err != nil {
 		return board, err
 	}
-	board = make([][]null.Int, rows)
+	board = make([][]null.Int, layers*rows)
 	for i := range board {
 		board[i] = make([]null.Int, cols)
 	}
 	for _, p := range points {
-		board[p.Row][p.Col] = null.NewInt(int(p.MineProximity), true)
+		board[int(p.Layer)*rows+int(p.Row)][p.Col] = null.NewInt(int(p.MineProximity), true)
 	}
 	return board, nil
 }
 
-func retrieveBoard(ctx context.Context, executor boil.ContextExecutor, gameID int64, rows, cols int, mask proximityMask) ([][]int, error) {
+func retrieveBoard(ctx context.Context, executor boil.ContextExecutor, gameID int64, layers, rows, cols int, mask proximityMask) ([][]int, error) {
 	var board [][]int
 	points, err := retrieveMines(ctx, executor, gameID, mask)
 	if err != nil {
 		return board, err
 	}
-	board = make([][]int, rows)
+	board = make([][]int, layers*rows)
 	for i := range board {
 		board[i] = make([]int, cols)
 	}
 	for _, p := range points {
-		board[p.Row][p.Col] = int(p.MineProximity)
+		board[int(p.Layer)*rows+int(p.Row)][p.Col] = int(p.MineProximity)
 	}
 	return board, nil
 }
@@ -730,20 +2686,26 @@ func retrieveMines(ctx context.Context, executor boil.ContextExecutor, gameID in
 		where = qm.Where("game_id = ?", gameID)
 	}
 	return models.GameBoardPoints(
-		qm.Select("row, col, mine_proximity"),
+		qm.Select("layer, row, col, mine_proximity"),
 		where,
 	).All(ctx, executor)
 }
 
 func toAlgebraOperation(o *models.GameOperation) (algebra.Operation, error) {
 	opType := operationType(o.Operation)
-	return algebra.NewOperation(opType, int(o.Row), int(o.Col))
+	return algebra.NewLayeredOperation(opType, int(o.Layer), int(o.Row), int(o.Col))
 }
 
 func operationType(strOp string) algebra.OperationType {
 	opType := algebra.OpMark
 	if strOp == models.MineOperationReveal {
 		opType = algebra.OpReveal
+	} else if strOp == models.MineOperationChord {
+		opType = algebra.OpChord
+	} else if strOp == models.MineOperationHint {
+		opType = algebra.OpHint
+	} else if strOp == models.MineOperationUndo {
+		opType = algebra.OpUndo
 	}
 	return opType
 }
@@ -752,16 +2714,45 @@ func operationTypeStr(t algebra.OperationType) string {
 	opTypeStr := models.MineOperationMark
 	if t == algebra.OpReveal {
 		opTypeStr = models.MineOperationReveal
+	} else if t == algebra.OpChord {
+		opTypeStr = models.MineOperationChord
+	} else if t == algebra.OpHint {
+		opTypeStr = models.MineOperationHint
+	} else if t == algebra.OpUndo {
+		opTypeStr = models.MineOperationUndo
 	}
 	return opTypeStr
 }
 
+// markOffset returns the value that marking an unrevealed point has added to its mine proximity
+func markOffset(p algebra.MineProximity) int {
+	if p < algebra.SuspectMine {
+		return -2 * algebra.MarkOffset
+	} else if p < algebra.UnrevealedMine {
+		return -algebra.MarkOffset
+	}
+	return 0
+}
+
+// hiddenMineProximity returns the mine proximity of a point before it was revealed or marked
+func hiddenMineProximity(p algebra.MineProximity) algebra.MineProximity {
+	if p >= 0 {
+		return -p - 1
+	}
+	return p - markOffset(p)
+}
+
+// isMine returns true if an unrevealed point, marked or not, has a mine
+func isMine(p algebra.MineProximity) bool {
+	return p-markOffset(p) == algebra.UnrevealedMine
+}
+
 func proximityToState(p algebra.MineProximity) int {
-	if p <= -21 {
+	if p < algebra.SuspectMine {
 		return StateMarkedMine
-	} else if p > -21 && p <= -11 {
+	} else if p < algebra.UnrevealedMine {
 		return StateSuspectMine
-	} else if p > -11 && p <= -1 {
+	} else if p <= -1 {
 		return StateNotRevealed
 	}
 	return StateRevealed
@@ -775,6 +2766,7 @@ func markOperationNotApplied(confirmation *OperationConfirmation, newMineProximi
 
 func buildOperationResult(oper Operation, mp algebra.MineProximity) OperationResult {
 	opResult := OperationResult{
+		Layer:      oper.Layer,
 		Row:        oper.Row,
 		Col:        oper.Col,
 		PointState: proximityToState(mp),
@@ -3,8 +3,12 @@ package game
 import (
 	"context"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/security"
 )
 
 // 10	 198611863 ns/op	 3883188 B/op	   50122 allocs/op
@@ -47,7 +51,7 @@ func BenchmarkRetrieveRowCol(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		randomRow := random.Intn(gameRows - 1)
 		randomCol := random.Intn(gameCols - 1)
-		_, err = api.retrieveRowCol(ctx, user, pGame.ID, randomRow, randomCol)
+		_, err = api.retrieveRowCol(ctx, api.db, user, pGame.ID, 0, randomRow, randomCol)
 		if err != nil {
 			b.Fatalf("error retrieving row col %v\n", err)
 		}
@@ -74,9 +78,56 @@ func BenchmarkUpdateRowCol(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		randomRow := random.Intn(gameRows - 1)
 		randomCol := random.Intn(gameCols - 1)
-		err = api.updateRowCol(ctx, pGame.ID, randomRow, randomCol, 0)
+		err = api.updateRowCol(ctx, api.db, pGame.ID, 0, randomRow, randomCol, 0)
 		if err != nil {
 			b.Fatalf("error updating row col %v\n", err)
 		}
 	}
 }
+
+// BenchmarkConcurrentOperations applies the operations of several players to the same co-op board at the same
+// time. The operations wait for the ones ahead of them instead of retrying, so none of them times out.
+func BenchmarkConcurrentOperations(b *testing.B) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, b, username)
+	pGame := ProspectGame{
+		Rows:    gameRows,
+		Cols:    gameCols,
+		Mines:   gameMines,
+		Private: false,
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	if err != nil {
+		b.Fatalf("error creating game %v\n", err)
+	}
+	players := []security.JWTUser{user, anotherUser}
+	var clients int64
+	b.SetParallelism(8)
+	// do not count first insertion time
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		player := players[atomic.AddInt64(&clients, 1)%int64(len(players))]
+		random := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+		operationID := 1
+		for pb.Next() {
+			oper := Operation{
+				ID:     operationID,
+				GameID: pGame.ID,
+				Row:    random.Intn(gameRows),
+				Col:    random.Intn(gameCols),
+				Op:     algebra.OpMark,
+			}
+			operCtx, cancel := context.WithTimeout(ctx, socketOperationTimeout)
+			confirmation, err := api.ApplyOperation(operCtx, player, oper)
+			cancel()
+			if err != nil {
+				b.Errorf("error applying operation %v\n", err)
+				return
+			}
+			if confirmation.Operation.ID >= operationID {
+				// the client knows every operation up to the one just applied
+				operationID = confirmation.Operation.ID + 1
+			}
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestApplyChordOperations(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	tests := []gameTest{
+		{
+			// chord should have no effect when the marked siblings do not match the mine proximity
+			game: &models.Game{
+				CreatorID: user.ID,
+				Rows:      int16(3),
+				Cols:      int16(3),
+				Mines:     int16(1),
+				Private:   false,
+			},
+			initialBoard: [][]int{
+				{-1, -2, -28},
+				{-1, 1, -2},
+				{-1, -1, -1},
+			},
+			operation: Operation{
+				ID:  1,
+				Row: 1,
+				Col: 1,
+				Op:  algebra.OpChord,
+			},
+			expectedConfirmation: OperationConfirmation{
+				Operation: Operation{
+					ID:      0,
+					Row:     1,
+					Col:     1,
+					Op:      algebra.OpChord,
+					Applied: false,
+					Result: []OperationResult{
+						{Row: 1, Col: 1, MineProximity: 1, PointState: StateRevealed},
+					},
+				},
+				Status: Status{
+					Rows: 3,
+					Cols: 3,
+				},
+			},
+			expectedBoard: [][]int{
+				{-1, -2, -28},
+				{-1, 1, -2},
+				{-1, -1, -1},
+			},
+		},
+		{
+			// chord should reveal all the unmarked siblings and win the game
+			game: &models.Game{
+				CreatorID: user.ID,
+				Rows:      int16(3),
+				Cols:      int16(3),
+				Mines:     int16(1),
+				Private:   false,
+			},
+			initialBoard: [][]int{
+				{-1, -2, -84},
+				{-1, 1, -2},
+				{-1, -1, -1},
+			},
+			operation: Operation{
+				ID:  1,
+				Row: 1,
+				Col: 1,
+				Op:  algebra.OpChord,
+			},
+			expectedConfirmation: OperationConfirmation{
+				Operation: Operation{
+					ID:      1,
+					Row:     1,
+					Col:     1,
+					Op:      algebra.OpChord,
+					Applied: true,
+					Result: []OperationResult{
+						{Row: 1, Col: 1, MineProximity: 1, PointState: StateRevealed},
+						{Row: 0, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 0, Col: 1, MineProximity: 1, PointState: StateRevealed},
+						{Row: 1, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 2, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 2, Col: 1, MineProximity: 0, PointState: StateRevealed},
+						{Row: 1, Col: 2, MineProximity: 1, PointState: StateRevealed},
+						{Row: 2, Col: 2, MineProximity: 0, PointState: StateRevealed},
+					},
+				},
+				Status: Status{
+					Won:  true,
+					Rows: 3,
+					Cols: 3,
+					Board: [][]int{
+						{0, 1, -84},
+						{0, 1, 1},
+						{0, 0, 0},
+					},
+				},
+			},
+			expectedBoard: [][]int{
+				{0, 1, -84},
+				{0, 1, 1},
+				{0, 0, 0},
+			},
+		},
+		{
+			// chord with a wrong mark should reveal the mine and lose the game
+			game: &models.Game{
+				CreatorID: user.ID,
+				Rows:      int16(3),
+				Cols:      int16(3),
+				Mines:     int16(1),
+				Private:   false,
+			},
+			initialBoard: [][]int{
+				{-57, -2, -28},
+				{-1, 1, -2},
+				{-1, -1, -1},
+			},
+			operation: Operation{
+				ID:  1,
+				Row: 1,
+				Col: 1,
+				Op:  algebra.OpChord,
+			},
+			expectedConfirmation: OperationConfirmation{
+				Operation: Operation{
+					ID:      1,
+					Row:     1,
+					Col:     1,
+					Op:      algebra.OpChord,
+					Applied: true,
+					Result: []OperationResult{
+						{Row: 1, Col: 1, MineProximity: 1, PointState: StateRevealed},
+						{Row: 0, Col: 1, MineProximity: 1, PointState: StateRevealed},
+						{Row: 0, Col: 2, MineProximity: algebra.RevealedMine, PointState: StateRevealed},
+						{Row: 1, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 2, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 2, Col: 1, MineProximity: 0, PointState: StateRevealed},
+						{Row: 1, Col: 2, MineProximity: 1, PointState: StateRevealed},
+						{Row: 2, Col: 2, MineProximity: 0, PointState: StateRevealed},
+					},
+				},
+				Status: Status{
+					Lost: true,
+					Rows: 3,
+					Cols: 3,
+					Board: [][]int{
+						{-57, 1, 27},
+						{0, 1, 1},
+						{0, 0, 0},
+					},
+				},
+			},
+			expectedBoard: [][]int{
+				{-57, 1, 27},
+				{0, 1, 1},
+				{0, 0, 0},
+			},
+		},
+	}
+	assertGameTests(ctx, t, user, api, tests)
+}
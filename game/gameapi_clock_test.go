@@ -0,0 +1,194 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestPauseGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(3),
+		Cols:      int16(3),
+		Mines:     int16(1),
+		Private:   true,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-1, -1, -1},
+		{-2, -2, -1},
+		{-28, -2, -1},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.ResumeGame(ctx, user, game.ID)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrGameNotPaused.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	confirmation, err := api.ApplyOperation(ctx, user, Operation{
+		ID:     1,
+		GameID: game.ID,
+		Row:    1,
+		Col:    1,
+		Op:     algebra.OpReveal,
+	})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if !confirmation.Operation.Applied {
+		t.Fatalf("expected operation to be applied but was %v\n", confirmation)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if !statefulGame.StartedAt.Valid {
+		t.Fatalf("expected the first operation to start the game clock\n")
+	}
+	statefulGame, err = api.PauseGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error pausing game %v\n", err)
+	}
+	if !statefulGame.PausedAt.Valid || statefulGame.Board != nil {
+		t.Fatalf("expected game to be paused with its board hidden but was %v\n", statefulGame)
+	}
+	_, err = api.PauseGame(ctx, user, game.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrGamePaused.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	_, err = api.ApplyOperation(ctx, user, Operation{
+		ID:     2,
+		GameID: game.ID,
+		Row:    0,
+		Col:    0,
+		Op:     algebra.OpReveal,
+	})
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	_, err = api.Hint(ctx, user, game.ID)
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	_, err = api.ResumeGame(ctx, anotherUser, game.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusNotFound,
+		Message: ErrGameNotExists.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	statefulGame, err = api.ResumeGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error resuming game %v\n", err)
+	}
+	if statefulGame.PausedAt.Valid || statefulGame.Board == nil {
+		t.Fatalf("expected game to be resumed with its board but was %v\n", statefulGame)
+	}
+	confirmation, err = api.ApplyOperation(ctx, user, Operation{
+		ID:     2,
+		GameID: game.ID,
+		Row:    0,
+		Col:    0,
+		Op:     algebra.OpReveal,
+	})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if !confirmation.Status.Won {
+		t.Fatalf("expected game to be won but was %v\n", confirmation.Status)
+	}
+	statefulGame, err = api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if statefulGame.Elapsed != confirmation.Status.Elapsed {
+		t.Fatalf("expected game elapsed time to be %d but was %d\n", confirmation.Status.Elapsed, statefulGame.Elapsed)
+	}
+}
+
+func TestPausePublicGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Private:   false,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.PauseGame(ctx, user, game.ID)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrGameNotSolo.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+}
+
+func TestPauseSharedGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Private:   true,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.InviteParticipant(ctx, user, game.ID, Invitation{PlayerID: anotherUser.ID, Role: models.ParticipantRolePlayer})
+	if err != nil {
+		t.Fatalf("error inviting player %v\n", err)
+	}
+	// a pending invitation does not share the game yet
+	_, err = api.PauseGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error pausing game %v\n", err)
+	}
+	_, err = api.ResumeGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error resuming game %v\n", err)
+	}
+	err = api.AcceptInvitation(ctx, anotherUser, game.ID)
+	if err != nil {
+		t.Fatalf("error accepting invitation %v\n", err)
+	}
+	_, err = api.PauseGame(ctx, user, game.ID)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrGameNotSolo.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+}
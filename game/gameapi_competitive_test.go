@@ -0,0 +1,126 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/volatiletech/null"
+)
+
+func TestCompetitiveGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	err := api.CreateGame(ctx, user, &ProspectGame{Rows: 3, Cols: 3, Mines: 1, Private: true, Competitive: true})
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrCompetitiveNotShared.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	game := &models.Game{
+		CreatorID:   user.ID,
+		Rows:        int16(2),
+		Cols:        int16(3),
+		Mines:       int16(1),
+		Private:     false,
+		Competitive: true,
+	}
+	err = api.storeGameBoard(ctx, user, game, [][]int{
+		{-1, -2, -28},
+		{-1, -2, -2},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	confirmation, err := api.ApplyOperation(ctx, anotherUser, Operation{ID: 1, GameID: game.ID, Row: 1, Col: 2, Op: algebra.OpReveal})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if len(confirmation.Status.Scores) != 1 || confirmation.Status.Scores[0].Score != 1 {
+		t.Fatalf("expected the player to score 1 point but scores were %v\n", confirmation.Status.Scores)
+	}
+	confirmation, err = api.ApplyOperation(ctx, user, Operation{ID: 2, GameID: game.ID, Row: 0, Col: 2, Op: algebra.OpReveal})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if confirmation.Status.Lost {
+		t.Fatalf("expected the game to go on while a player is standing\n")
+	}
+	if len(confirmation.Status.Scores) != 2 || !confirmation.Status.Scores[1].Eliminated || confirmation.Status.Scores[1].Score != -minePenalty {
+		t.Fatalf("expected the player that hit the mine to be eliminated but scores were %v\n", confirmation.Status.Scores)
+	}
+	_, err = api.ApplyOperation(ctx, user, Operation{ID: 3, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal})
+	expectedErr = response.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: ErrPlayerEliminated.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	confirmation, err = api.ApplyOperation(ctx, anotherUser, Operation{ID: 3, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if !confirmation.Status.Won || confirmation.Status.Winner == nil || confirmation.Status.Winner.ID != anotherUser.ID {
+		t.Fatalf("expected the game to be won by player %d but status was %v\n", anotherUser.ID, confirmation.Status)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if !statefulGame.Competitive || len(statefulGame.Scores) != 2 || statefulGame.Scores[0].Score != 5 {
+		t.Fatalf("expected the winner to have scored 5 points but scores were %v\n", statefulGame.Scores)
+	}
+	if statefulGame.Winner == nil || statefulGame.Winner.ID != anotherUser.ID {
+		t.Fatalf("expected the winner to be player %d but was %v\n", anotherUser.ID, statefulGame.Winner)
+	}
+}
+
+func TestOperationScore(t *testing.T) {
+	results := []OperationResult{{}, {}, {}}
+	testTable := []struct {
+		oper          Operation
+		mineProximity algebra.MineProximity
+		score         int
+	}{
+		{oper: Operation{Op: algebra.OpReveal, Result: results[:1]}, mineProximity: 2, score: 1},
+		{oper: Operation{Op: algebra.OpReveal, Result: results}, mineProximity: 0, score: 3},
+		{oper: Operation{Op: algebra.OpChord, Result: results}, mineProximity: 1, score: 2},
+		{oper: Operation{Op: algebra.OpChord, Result: results}, mineProximity: algebra.RevealedMine, score: -minePenalty},
+		{oper: Operation{Op: algebra.OpReveal, Result: results[:1]}, mineProximity: algebra.RevealedMine, score: -minePenalty},
+		{oper: Operation{Op: algebra.OpMark, Result: results[:1]}, mineProximity: algebra.SuspectMine, score: 0},
+	}
+	for i, test := range testTable {
+		score := operationScore(test.oper, test.mineProximity)
+		if score != test.score {
+			t.Fatalf("test %d failed: expected score %d but got %d\n", i, test.score, score)
+		}
+	}
+}
+
+func TestRankScores(t *testing.T) {
+	scores := []PlayerScore{
+		{Player: Creator{ID: 1}, Score: 20, Eliminated: true, LastScoredID: null.IntFrom(2)},
+		{Player: Creator{ID: 2}, Score: 5, LastScoredID: null.IntFrom(9)},
+		{Player: Creator{ID: 3}, Score: 0},
+		{Player: Creator{ID: 4}, Score: 5, LastScoredID: null.IntFrom(4)},
+	}
+	rankScores(scores)
+	expected := []int64{4, 2, 3, 1}
+	for i, id := range expected {
+		if scores[i].Player.ID != id {
+			t.Fatalf("expected player %d to be ranked %d but was player %d\n", id, i, scores[i].Player.ID)
+		}
+	}
+	if w := winner(scores); w == nil || w.ID != 4 {
+		t.Fatalf("expected player 4 to win but was %v\n", w)
+	}
+	if w := winner(scores[3:]); w != nil {
+		t.Fatalf("expected no winner when every player was eliminated but was %v\n", w)
+	}
+}
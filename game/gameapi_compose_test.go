@@ -23,9 +23,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{1, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			existingOperations: models.GameOperationSlice{
 				{
@@ -105,9 +105,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{1, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -120,9 +120,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -20, -2},
+				{1, -56, -2},
 				{1, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			existingOperations: models.GameOperationSlice{
 				{
@@ -147,7 +147,7 @@ func TestApplyComposeOperations(t *testing.T) {
 					Operation:     "mark",
 					Row:           0,
 					Col:           1,
-					MineProximity: -20,
+					MineProximity: algebra.SuspectMine,
 				},
 			},
 			operation: Operation{
@@ -225,9 +225,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -20, -2},
+				{1, -56, -2},
 				{1, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -240,9 +240,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{1, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			existingOperations: models.GameOperationSlice{
 				{
@@ -322,9 +322,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{1, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -337,9 +337,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{1, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			existingOperations: models.GameOperationSlice{
 				{
@@ -419,9 +419,9 @@ func TestApplyComposeOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{1, 2, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -434,7 +434,7 @@ func TestApplyComposeOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -10, 1},
+				{1, -28, 1},
 				{1, -2, 1},
 				{0, 0, 0},
 			},
@@ -515,14 +515,14 @@ func TestApplyComposeOperations(t *testing.T) {
 					Cols: 3,
 					Won:  true,
 					Board: [][]int{
-						{1, -10, 1},
+						{1, -28, 1},
 						{1, 1, 1},
 						{0, 0, 0},
 					},
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, 1},
+				{1, -28, 1},
 				{1, 1, 1},
 				{0, 0, 0},
 			},
@@ -537,7 +537,7 @@ func TestApplyComposeOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -10, 1},
+				{1, -28, 1},
 				{1, -2, 1},
 				{0, 0, 0},
 			},
@@ -576,7 +576,7 @@ func TestApplyComposeOperations(t *testing.T) {
 						{
 							Row:           0,
 							Col:           1,
-							MineProximity: 9,
+							MineProximity: algebra.RevealedMine,
 							PointState:    StateRevealed,
 						},
 					},
@@ -618,14 +618,14 @@ func TestApplyComposeOperations(t *testing.T) {
 					Cols: 3,
 					Lost: true,
 					Board: [][]int{
-						{1, 9, 1},
+						{1, 27, 1},
 						{1, -2, 1},
 						{0, 0, 0},
 					},
 				},
 			},
 			expectedBoard: [][]int{
-				{1, 9, 1},
+				{1, 27, 1},
 				{1, -2, 1},
 				{0, 0, 0},
 			},
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/javiercbk/minesweeper/algebra"
 	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
 )
 
 func TestCreateGame(t *testing.T) {
@@ -102,14 +104,14 @@ func TestCreateGame(t *testing.T) {
 			t.Fatalf("test %d failed: expected err to be %v, but was %v\n", i, test.err, err)
 		}
 		if err == nil {
-			board, err := retrieveFullBoard(ctx, api.db, test.game.ID, test.game.Rows, test.game.Cols)
+			board, err := retrieveFullBoard(ctx, api.db, test.game.ID, 1, test.game.Rows, test.game.Cols)
 			if err != nil {
 				t.Fatalf("test %d failed: error retrieving game board %v\n", i, err)
 			}
 			mineCount := test.game.Mines
 			for row := range board {
 				for col := range board[row] {
-					if board[row][col] == -10 {
+					if board[row][col] == algebra.UnrevealedMine {
 						mineCount--
 					}
 				}
@@ -120,3 +122,208 @@ func TestCreateGame(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateSeededGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	seed := int64(1234)
+	pGame := ProspectGame{
+		Rows:             gameRows,
+		Cols:             gameCols,
+		Mines:            gameMines,
+		Seed:             &seed,
+		GeneratorVersion: GeneratorVersion + 1,
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrUnknownGeneratorVersion.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	boards := make([][][]int, 2)
+	for i := range boards {
+		pGame := ProspectGame{
+			Rows:  gameRows,
+			Cols:  gameCols,
+			Mines: gameMines,
+			Seed:  &seed,
+		}
+		err := api.CreateGame(ctx, user, &pGame)
+		if err != nil {
+			t.Fatalf("error creating game %d: %v\n", i, err)
+		}
+		if pGame.GeneratorVersion != GeneratorVersion {
+			t.Fatalf("expected generator version to be %d but was %d\n", GeneratorVersion, pGame.GeneratorVersion)
+		}
+		statefulGame, err := api.RetrieveGame(ctx, user, pGame.ID)
+		if err != nil {
+			t.Fatalf("error retrieving game %d: %v\n", i, err)
+		}
+		if statefulGame.Seed.Valid {
+			t.Fatalf("expected seed to be hidden until the game finishes but was %d\n", statefulGame.Seed.Int64)
+		}
+		boards[i], err = retrieveFullBoard(ctx, api.db, pGame.ID, 1, pGame.Rows, pGame.Cols)
+		if err != nil {
+			t.Fatalf("error retrieving game board %d: %v\n", i, err)
+		}
+	}
+	for row := range boards[0] {
+		for col := range boards[0][row] {
+			if boards[0][row][col] != boards[1][row][col] {
+				t.Fatalf("expected row %d, col %d to be %d but was %d\n", row, col, boards[0][row][col], boards[1][row][col])
+			}
+		}
+	}
+}
+
+func TestCreateNoGuessGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	pGame := ProspectGame{
+		Rows:    16,
+		Cols:    30,
+		Mines:   99,
+		NoGuess: true,
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	if err != nil {
+		t.Fatalf("error creating game: %v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, pGame.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game: %v\n", err)
+	}
+	if !statefulGame.NoGuess {
+		t.Fatalf("expected game to be a no guess game\n")
+	}
+	if pGame.SafeFirstReveal == nil || !*pGame.SafeFirstReveal {
+		t.Fatalf("expected no guess game to have a safe first reveal\n")
+	}
+}
+
+func TestCreateImpossibleNoGuessGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	pGame := ProspectGame{
+		Rows:    5,
+		Cols:    5,
+		Mines:   20,
+		NoGuess: true,
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrNoGuessBoardNotFound.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v but was %v\n", expectedErr, err)
+	}
+}
+
+func TestCreateHexGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	pGame := ProspectGame{
+		Rows:     gameRows,
+		Cols:     gameCols,
+		Mines:    gameMines,
+		Topology: "triangle",
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrUnknownTopology.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	pGame.Topology = models.BoardTopologyHex
+	err = api.CreateGame(ctx, user, &pGame)
+	if err != nil {
+		t.Fatalf("error creating game: %v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, pGame.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game: %v\n", err)
+	}
+	if statefulGame.Topology != models.BoardTopologyHex {
+		t.Fatalf("expected topology to be %s but was %s\n", models.BoardTopologyHex, statefulGame.Topology)
+	}
+}
+
+func TestCreateWrapGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	pGame := ProspectGame{
+		Rows:     9,
+		Cols:     9,
+		Mines:    10,
+		Topology: models.BoardTopologyHex,
+		Wrap:     true,
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrOddWrappedHexRows.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	pGame.Rows = 10
+	err = api.CreateGame(ctx, user, &pGame)
+	if err != nil {
+		t.Fatalf("error creating game: %v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, pGame.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game: %v\n", err)
+	}
+	if !statefulGame.Wrap {
+		t.Fatalf("expected game to wrap\n")
+	}
+}
+
+func TestCreateLayoutGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	pGame := ProspectGame{
+		Rows:   3,
+		Cols:   3,
+		Mines:  3,
+		Layout: []LayoutMine{{Row: 0, Col: 1}, {Row: 2, Col: 2}},
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrLayoutMines.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	pGame.Mines = 2
+	err = api.CreateGame(ctx, user, &pGame)
+	if err != nil {
+		t.Fatalf("error creating game: %v\n", err)
+	}
+	if *pGame.SafeFirstReveal {
+		t.Fatalf("expected a layout game not to move its mines on the first reveal\n")
+	}
+	board, err := retrieveFullBoard(ctx, api.db, pGame.ID, 1, pGame.Rows, pGame.Cols)
+	if err != nil {
+		t.Fatalf("error retrieving game board: %v\n", err)
+	}
+	expectedBoard := [][]int{
+		{-2, -28, -2},
+		{-2, -3, -3},
+		{-1, -2, -28},
+	}
+	for r := range expectedBoard {
+		for c := range expectedBoard[r] {
+			if board[r][c] != expectedBoard[r][c] {
+				t.Fatalf("expected row %d, col %d to be %d but was %d\n", r, c, expectedBoard[r][c], board[r][c])
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+func TestDeleteGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Private:   false,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.ApplyOperation(ctx, user, Operation{
+		ID:     1,
+		GameID: game.ID,
+		Row:    0,
+		Col:    0,
+		Op:     algebra.OpReveal,
+	})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	err = api.DeleteGame(ctx, anotherUser, game.ID, false)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: ErrNotGameCreator.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	err = api.DeleteGame(ctx, user, game.ID, true)
+	if err != nil {
+		t.Fatalf("error archiving game %v\n", err)
+	}
+	statefulGames, err := api.FindGames(ctx, user)
+	if err != nil {
+		t.Fatalf("error finding games %v\n", err)
+	}
+	for _, g := range statefulGames {
+		if g.ID == game.ID {
+			t.Fatalf("expected archived game to not be listed\n")
+		}
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving archived game %v\n", err)
+	}
+	if !statefulGame.Archived {
+		t.Fatalf("expected game to be archived but was %v\n", statefulGame)
+	}
+	err = api.DeleteGame(ctx, user, game.ID, false)
+	if err != nil {
+		t.Fatalf("error deleting game %v\n", err)
+	}
+	_, err = api.RetrieveGame(ctx, user, game.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("game %d does not exist", game.ID),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	points, err := models.GameBoardPoints(qm.Where("game_id = ?", game.ID)).Count(ctx, api.db)
+	if err != nil {
+		t.Fatalf("error counting game points %v\n", err)
+	}
+	operations, err := models.GameOperations(qm.Where("game_id = ?", game.ID)).Count(ctx, api.db)
+	if err != nil {
+		t.Fatalf("error counting game operations %v\n", err)
+	}
+	if points != 0 || operations != 0 {
+		t.Fatalf("expected game board and operations to be deleted but found %d points and %d operations\n", points, operations)
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestFindEvents(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(3),
+		Cols:      int16(3),
+		Mines:     int16(1),
+		Private:   false,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-1, -1, -1},
+		{-1, -2, -2},
+		{-1, -2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	eventChan := events.follow(game.ID)
+	defer events.unfollow(game.ID, eventChan)
+	_, err = api.ApplyOperation(ctx, user, Operation{ID: 1, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal})
+	if err != nil {
+		t.Fatalf("error applying operation: %v\n", err)
+	}
+	pushed := <-eventChan
+	if pushed.Operation == nil || pushed.Operation.ID != 1 || len(pushed.Operation.Result) != 9 {
+		t.Fatalf("expected the reveal of the whole board to be pushed but was %v\n", pushed)
+	}
+	if pushed.Status == nil || !pushed.Status.Won || len(pushed.Status.Board) != 3 {
+		t.Fatalf("expected the won status to be pushed with the board but was %v\n", pushed.Status)
+	}
+	missed, err := api.FindEvents(ctx, user, game.ID, 0)
+	if err != nil {
+		t.Fatalf("error finding events %v\n", err)
+	}
+	if len(missed) != 2 {
+		t.Fatalf("expected an operation and the final status but got %d events\n", len(missed))
+	}
+	if missed[0].Operation == nil || missed[0].Operation.ID != 1 || len(missed[0].Operation.Result) != 9 {
+		t.Fatalf("expected the reveal to have the whole revealed area but was %v\n", missed[0].Operation)
+	}
+	if missed[1].Status == nil || !missed[1].Status.Won || missed[1].Status.Board[2][2] != algebra.UnrevealedMine {
+		t.Fatalf("expected the final status with the whole board but was %v\n", missed[1].Status)
+	}
+	missed, err = api.FindEvents(ctx, user, game.ID, 1)
+	if err != nil {
+		t.Fatalf("error finding events %v\n", err)
+	}
+	if len(missed) != 1 || missed[0].Status == nil {
+		t.Fatalf("expected only the final status but got %v\n", missed)
+	}
+}
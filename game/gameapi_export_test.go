@@ -0,0 +1,104 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/gamefile"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestExportImportGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID:        user.ID,
+		Rows:             int16(2),
+		Cols:             int16(2),
+		Mines:            int16(1),
+		Private:          false,
+		Seed:             42,
+		GeneratorVersion: GeneratorVersion,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	operations := []Operation{
+		{ID: 1, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal},
+		{ID: 2, GameID: game.ID, Row: 1, Col: 1, Op: algebra.OpReveal},
+	}
+	for i, oper := range operations {
+		_, err = api.ApplyOperation(ctx, user, oper)
+		if err != nil {
+			t.Fatalf("error applying operation %d: %v\n", i, err)
+		}
+	}
+	f, err := api.ExportGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error exporting game %v\n", err)
+	}
+	if f.Won || f.Seed != 42 || len(f.Operations) != 2 || f.Operations[1].Player != username {
+		t.Fatalf("expected a lost game with seed 42 and 2 operations but was %v\n", f)
+	}
+	expectedBoard := [][]int{
+		{1, -2},
+		{-2, 27},
+	}
+	if !reflect.DeepEqual(f.Board, expectedBoard) {
+		t.Fatalf("expected board %v but was %v\n", expectedBoard, f.Board)
+	}
+	_, err = api.ImportGame(ctx, anotherUser, gamefile.File{}, true)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: gamefile.ErrInvalidFile.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	tampered := f
+	tampered.Board = [][]int{
+		{2, -2},
+		{-2, 27},
+	}
+	_, err = api.ImportGame(ctx, anotherUser, tampered, true)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrMineProximityMismatch.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	importedID, err := api.ImportGame(ctx, anotherUser, f, true)
+	if err != nil {
+		t.Fatalf("error importing game %v\n", err)
+	}
+	imported, err := api.ExportGame(ctx, anotherUser, importedID)
+	if err != nil {
+		t.Fatalf("error exporting imported game %v\n", err)
+	}
+	if !reflect.DeepEqual(imported.Board, f.Board) || imported.Seed != f.Seed || imported.Elapsed != f.Elapsed {
+		t.Fatalf("expected imported game to be %v but was %v\n", f, imported)
+	}
+	// the operations belong to the player importing the game, whatever the file says
+	for i, o := range imported.Operations {
+		expected := f.Operations[i]
+		if o.ID != expected.ID || o.Op != expected.Op || o.Row != expected.Row || o.Col != expected.Col || o.Player != anotherUsername {
+			t.Fatalf("expected imported operation %d to be %v but was %v\n", i, expected, o)
+		}
+	}
+	statefulGame, err := api.RetrieveGame(ctx, anotherUser, importedID)
+	if err != nil {
+		t.Fatalf("error retrieving imported game %v\n", err)
+	}
+	if statefulGame.Creator.ID != anotherUser.ID || !statefulGame.FinishedAt.Valid || !statefulGame.Private {
+		t.Fatalf("expected imported game to be private, finished and created by %d but was %v\n", anotherUser.ID, statefulGame)
+	}
+}
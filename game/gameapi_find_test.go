@@ -62,6 +62,10 @@ func TestFindGame(t *testing.T) {
 		if err != nil {
 			t.Fatalf("error inserting game %d: %v", i, err)
 		}
+		err = insertOwner(ctx, api.db, games[i])
+		if err != nil {
+			t.Fatalf("error inserting the owner of game %d: %v", i, err)
+		}
 	}
 	gamesFound, err := api.FindGames(ctx, user)
 	if err != nil {
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+func TestHint(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(3),
+		Cols:      int16(3),
+		Mines:     int16(1),
+		Private:   false,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{0, 0, 0},
+		{1, 1, 0},
+		{-28, -2, -1},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	hint, err := api.Hint(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error requesting hint %v\n", err)
+	}
+	if hint.Action != HintReveal || hint.Row != 2 || hint.Col != 1 || hint.OperationID != 1 {
+		t.Fatalf("expected a reveal hint on row 2, col 1 with operation id 1 but was %v\n", hint)
+	}
+	hintOperation, err := models.GameOperations(qm.Where("game_id = ? AND operation_id = ?", game.ID, 1)).One(ctx, api.db)
+	if err != nil {
+		t.Fatalf("error retrieving hint operation %v\n", err)
+	}
+	if hintOperation.Operation != models.MineOperationHint || hintOperation.PlayerID != user.ID {
+		t.Fatalf("expected hint operation to be recorded but was %v\n", hintOperation)
+	}
+	if hintOperation.MineProximity != hintMineProximity {
+		t.Fatalf("expected the hint operation to hide the mine proximity of the point but was %d\n", hintOperation.MineProximity)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if statefulGame.Hints != 1 {
+		t.Fatalf("expected game to have 1 hint but had %d\n", statefulGame.Hints)
+	}
+	// an operation sent without knowing about the hint is still applied
+	confirmation, err := api.ApplyOperation(ctx, user, Operation{
+		ID:     1,
+		GameID: game.ID,
+		Row:    2,
+		Col:    1,
+		Op:     algebra.OpReveal,
+	})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if !confirmation.Operation.Applied || confirmation.Operation.ID != 2 || len(confirmation.DeltaOperations) != 1 {
+		t.Fatalf("expected operation to be applied after the hint but was %v\n", confirmation)
+	}
+	if confirmation.DeltaOperations[0].Op != algebra.OpHint {
+		t.Fatalf("expected delta operation to be a hint but was %v\n", confirmation.DeltaOperations[0])
+	}
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestCreateLayeredGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	pGame := ProspectGame{
+		Rows:     5,
+		Cols:     5,
+		Mines:    10,
+		Layers:   3,
+		Topology: models.BoardTopologyHex,
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrUnsupportedLayers.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	pGame.Topology = ""
+	err = api.CreateGame(ctx, user, &pGame)
+	if err != nil {
+		t.Fatalf("error creating game: %v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, pGame.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game: %v\n", err)
+	}
+	if statefulGame.Layers != 3 || len(statefulGame.Board) != 15 {
+		t.Fatalf("expected game to have 3 layers stacked in 15 rows but had %d layers and %d rows\n", statefulGame.Layers, len(statefulGame.Board))
+	}
+}
+
+func TestApplyLayeredOperations(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Layers:    int16(2),
+		Private:   false,
+	}
+	// the mine is on the first layer, every other point is next to it
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-28, -2},
+		{-2, -2},
+		{-2, -2},
+		{-2, -2},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	confirmation, err := api.ApplyOperation(ctx, user, Operation{
+		ID:     1,
+		GameID: game.ID,
+		Layer:  1,
+		Row:    0,
+		Col:    0,
+		Op:     algebra.OpReveal,
+	})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	result := confirmation.Operation.Result
+	if !confirmation.Operation.Applied || len(result) != 1 || result[0].Layer != 1 || result[0].MineProximity != 1 {
+		t.Fatalf("expected the point of the second layer to be revealed but was %v\n", confirmation)
+	}
+	mineProximity, err := api.retrieveRowCol(ctx, api.db, user, game.ID, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("error retrieving point %v\n", err)
+	}
+	if mineProximity != algebra.UnrevealedMine {
+		t.Fatalf("expected the first layer to be unchanged but was %d\n", mineProximity)
+	}
+	_, err = api.ApplyOperation(ctx, user, Operation{
+		ID:     2,
+		GameID: game.ID,
+		Layer:  2,
+		Row:    0,
+		Col:    0,
+		Op:     algebra.OpReveal,
+	})
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrInvalidRowCols.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+}
@@ -23,9 +23,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -55,9 +55,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -70,9 +70,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -102,9 +102,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{-12, -10, -2},
+				{-30, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -117,9 +117,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-12, -10, -2},
+				{-30, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -149,9 +149,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{-22, -10, -2},
+				{-58, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -164,9 +164,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-22, -10, -2},
+				{-58, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -196,9 +196,9 @@ func TestApplyMarkOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 	}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestGameParticipants(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Private:   true,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	notExistsErr := response.HTTPError{
+		Code:    http.StatusNotFound,
+		Message: ErrGameNotExists.Error(),
+	}
+	_, err = api.InviteParticipant(ctx, anotherUser, game.ID, Invitation{PlayerID: anotherUser.ID, Role: models.ParticipantRolePlayer})
+	if err != notExistsErr {
+		t.Fatalf("expected err to be %v, but was %v\n", notExistsErr, err)
+	}
+	participant, err := api.InviteParticipant(ctx, user, game.ID, Invitation{PlayerID: anotherUser.ID, Role: models.ParticipantRoleSpectator})
+	if err != nil {
+		t.Fatalf("error inviting player %v\n", err)
+	}
+	if participant.Player.ID != anotherUser.ID || participant.AcceptedAt.Valid {
+		t.Fatalf("expected a pending invitation of player %d but was %v\n", anotherUser.ID, participant)
+	}
+	_, err = api.InviteParticipant(ctx, user, game.ID, Invitation{PlayerID: anotherUser.ID, Role: models.ParticipantRolePlayer})
+	expectedErr := response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrAlreadyParticipant.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	games, err := api.FindGames(ctx, anotherUser)
+	if err != nil {
+		t.Fatalf("error finding games %v\n", err)
+	}
+	invited := false
+	for _, g := range games {
+		invited = invited || (g.ID == game.ID && g.Invited)
+	}
+	if !invited {
+		t.Fatalf("expected the invitation to game %d to be listed but games were %v\n", game.ID, games)
+	}
+	// the game stays hidden until the invitation is accepted
+	_, err = api.RetrieveGame(ctx, anotherUser, game.ID)
+	if err != notExistsErr {
+		t.Fatalf("expected err to be %v, but was %v\n", notExistsErr, err)
+	}
+	err = api.AcceptInvitation(ctx, anotherUser, game.ID)
+	if err != nil {
+		t.Fatalf("error accepting invitation %v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, anotherUser, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if statefulGame.Role.String != models.ParticipantRoleSpectator {
+		t.Fatalf("expected the player to be a spectator but was %v\n", statefulGame.Role)
+	}
+	oper := Operation{ID: 1, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal}
+	_, err = api.ApplyOperation(ctx, anotherUser, oper)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: ErrSpectator.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	participants, err := api.FindParticipants(ctx, anotherUser, game.ID)
+	if err != nil {
+		t.Fatalf("error finding participants %v\n", err)
+	}
+	if len(participants) != 2 || participants[0].Role != models.ParticipantRoleOwner || participants[1].Player.ID != anotherUser.ID {
+		t.Fatalf("expected the owner and the spectator to participate but participants were %v\n", participants)
+	}
+	err = api.RevokeParticipant(ctx, anotherUser, game.ID, user.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: ErrNotGameCreator.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	err = api.RevokeParticipant(ctx, user, game.ID, user.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrRevokeOwner.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	err = api.RevokeParticipant(ctx, user, game.ID, anotherUser.ID)
+	if err != nil {
+		t.Fatalf("error revoking participant %v\n", err)
+	}
+	_, err = api.ApplyOperation(ctx, anotherUser, oper)
+	if err != notExistsErr {
+		t.Fatalf("expected err to be %v, but was %v\n", notExistsErr, err)
+	}
+	_, err = api.ApplyOperation(ctx, user, oper)
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+}
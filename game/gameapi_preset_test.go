@@ -0,0 +1,62 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/http/response"
+)
+
+func TestFindPresets(t *testing.T) {
+	ctx := context.Background()
+	api, _, _ := setUp(ctx, t, username)
+	presets, err := api.FindPresets(ctx)
+	if err != nil {
+		t.Fatalf("error finding presets: %v\n", err)
+	}
+	expected := []Preset{
+		{Name: "beginner", Rows: 9, Cols: 9, Mines: 10},
+		{Name: "intermediate", Rows: 16, Cols: 16, Mines: 40},
+		{Name: "expert", Rows: 16, Cols: 30, Mines: 99},
+	}
+	if len(presets) < len(expected) {
+		t.Fatalf("expected at least %d presets but got %d\n", len(expected), len(presets))
+	}
+	for i := range expected {
+		if presets[i] != expected[i] {
+			t.Fatalf("expected preset %d to be %v but was %v\n", i, expected[i], presets[i])
+		}
+	}
+}
+
+func TestCreatePresetGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	pGame := ProspectGame{
+		Preset: "impossible",
+	}
+	err := api.CreateGame(ctx, user, &pGame)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrUnknownPreset.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	pGame.Preset = "expert"
+	err = api.CreateGame(ctx, user, &pGame)
+	if err != nil {
+		t.Fatalf("error creating game: %v\n", err)
+	}
+	if pGame.Rows != 16 || pGame.Cols != 30 || pGame.Mines != 99 {
+		t.Fatalf("expected the expert board but got %d rows, %d cols and %d mines\n", pGame.Rows, pGame.Cols, pGame.Mines)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, pGame.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game: %v\n", err)
+	}
+	if statefulGame.Preset.String != "expert" {
+		t.Fatalf("expected game preset to be expert but was %v\n", statefulGame.Preset)
+	}
+}
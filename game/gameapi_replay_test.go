@@ -0,0 +1,75 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestReplayGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Private:   false,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.ReplayGame(ctx, user, game.ID)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrGameNotFinished.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	operations := []Operation{
+		{ID: 1, GameID: game.ID, Row: 1, Col: 1, Op: algebra.OpMark},
+		{ID: 2, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal},
+		{ID: 3, GameID: game.ID, Row: 0, Col: 1, Op: algebra.OpReveal},
+		{ID: 4, GameID: game.ID, Row: 1, Col: 0, Op: algebra.OpReveal},
+	}
+	for i, oper := range operations {
+		_, err = api.ApplyOperation(ctx, user, oper)
+		if err != nil {
+			t.Fatalf("error applying operation %d: %v\n", i, err)
+		}
+	}
+	replay, err := api.ReplayGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error replaying game %v\n", err)
+	}
+	if !replay.Won || replay.Rows != 2 || replay.Cols != 2 || replay.Mines != 1 {
+		t.Fatalf("expected a won 2x2 game with 1 mine but was %v\n", replay)
+	}
+	if len(replay.Layout) != 1 || replay.Layout[0] != (LayoutMine{Row: 1, Col: 1}) {
+		t.Fatalf("expected the mine to be on row 1, col 1 but was %v\n", replay.Layout)
+	}
+	if len(replay.Operations) != len(operations) {
+		t.Fatalf("expected %d operations but got %d\n", len(operations), len(replay.Operations))
+	}
+	for i, o := range replay.Operations {
+		oper := operations[i]
+		if o.ID != oper.ID || o.Op != oper.Op || o.Row != oper.Row || o.Col != oper.Col {
+			t.Fatalf("expected operation %d to be %v but was %v\n", i, oper, o)
+		}
+		if o.Player.ID != user.ID || !o.CreatedAt.Valid {
+			t.Fatalf("expected operation %d to be made by player %d with a timestamp but was %v\n", i, user.ID, o)
+		}
+	}
+	if replay.Operations[0].Result.PointState != StateSuspectMine || replay.Operations[1].Result.MineProximity != 1 {
+		t.Fatalf("expected the operation results to be recorded but were %v\n", replay.Operations)
+	}
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestResignGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Private:   false,
+	}
+	board := [][]int{
+		{-2, -2},
+		{-2, -28},
+	}
+	err := api.storeGameBoard(ctx, user, game, board)
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.ResignGame(ctx, anotherUser, game.ID)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: ErrNotGameCreator.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	status, err := api.ResignGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error resigning game %v\n", err)
+	}
+	expectedStatus := Status{
+		Layers:   1,
+		Rows:     2,
+		Cols:     2,
+		Resigned: true,
+		Board:    board,
+	}
+	err = assertStatus(expectedStatus, status)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if !statefulGame.FinishedAt.Valid || statefulGame.Won.Bool || !statefulGame.Resigned {
+		t.Fatalf("expected game to be resigned but was %v\n", statefulGame)
+	}
+	_, err = api.ResignGame(ctx, user, game.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusNotFound,
+		Message: ErrGameFinished.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+}
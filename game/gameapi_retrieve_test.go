@@ -36,8 +36,8 @@ func assertStatefulGame(game *models.Game, board [][]int, statefulGame StatefulG
 	if statefulGame.Creator.Name == "" {
 		return fmt.Errorf("expected game creator name not be empty")
 	}
-	if statefulGame.LastOperationID != 1 {
-		return fmt.Errorf("expected last operation id to be 1 but was %d", statefulGame.LastOperationID)
+	if !statefulGame.LastOperationID.Valid || statefulGame.LastOperationID.Int != 1 {
+		return fmt.Errorf("expected last operation id to be 1 but was %d", statefulGame.LastOperationID.Int)
 	}
 	for row := range statefulGame.Board {
 		for col := range statefulGame.Board[row] {
@@ -67,9 +67,9 @@ func TestRetrieveGame(t *testing.T) {
 				Private:   true,
 			},
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			existingOperations: models.GameOperationSlice{
 				{
@@ -97,9 +97,9 @@ func TestRetrieveGame(t *testing.T) {
 			},
 			failureGameID: 123,
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			existingOperations: models.GameOperationSlice{
 				{
@@ -126,9 +126,9 @@ func TestRetrieveGame(t *testing.T) {
 				Private:   true,
 			},
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			existingOperations: models.GameOperationSlice{
 				{
@@ -28,9 +28,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private:   true,
 			},
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -52,9 +52,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -80,9 +80,9 @@ func TestApplyRevealOperations(t *testing.T) {
 			// should not allow to apply operations on finished games
 			finished: true,
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -105,9 +105,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				FinishedAt: null.NewTime(time.Now().UTC(), true),
 			},
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID: 1,
@@ -130,9 +130,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -156,9 +156,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-22, -10, -2},
+				{-58, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -181,9 +181,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-12, -10, -2},
+				{-30, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -206,9 +206,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -238,9 +238,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -253,9 +253,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private:   false,
 			},
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -285,9 +285,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -300,9 +300,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private: false,
 			},
 			initialBoard: [][]int{
-				{-2, -10, -2},
+				{-2, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 			operation: Operation{
 				ID:  1,
@@ -332,9 +332,9 @@ func TestApplyRevealOperations(t *testing.T) {
 				},
 			},
 			expectedBoard: [][]int{
-				{1, -10, -2},
+				{1, -28, -2},
 				{-2, -3, -3},
-				{-1, -2, -10},
+				{-1, -2, -28},
 			},
 		},
 		{
@@ -347,7 +347,7 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private: false,
 			},
 			initialBoard: [][]int{
-				{0, -2, -10},
+				{0, -2, -28},
 				{0, 1, 1},
 				{0, 0, 0},
 			},
@@ -378,14 +378,14 @@ func TestApplyRevealOperations(t *testing.T) {
 					Rows: 3,
 					Cols: 3,
 					Board: [][]int{
-						{0, 1, -10},
+						{0, 1, -28},
 						{0, 1, 1},
 						{0, 0, 0},
 					},
 				},
 			},
 			expectedBoard: [][]int{
-				{0, 1, -10},
+				{0, 1, -28},
 				{0, 1, 1},
 				{0, 0, 0},
 			},
@@ -400,7 +400,7 @@ func TestApplyRevealOperations(t *testing.T) {
 				Private: false,
 			},
 			initialBoard: [][]int{
-				{0, -2, -10},
+				{0, -2, -28},
 				{0, 1, 1},
 				{0, 0, 0},
 			},
@@ -421,7 +421,7 @@ func TestApplyRevealOperations(t *testing.T) {
 						{
 							Row:           0,
 							Col:           2,
-							MineProximity: 9,
+							MineProximity: algebra.RevealedMine,
 							PointState:    StateRevealed,
 						},
 					},
@@ -431,18 +431,122 @@ func TestApplyRevealOperations(t *testing.T) {
 					Rows: 3,
 					Cols: 3,
 					Board: [][]int{
-						{0, -2, 9},
+						{0, -2, 27},
 						{0, 1, 1},
 						{0, 0, 0},
 					},
 				},
 			},
 			expectedBoard: [][]int{
-				{0, -2, 9},
+				{0, -2, 27},
 				{0, 1, 1},
 				{0, 0, 0},
 			},
 		},
+		{
+			game: &models.Game{
+				CreatorID: user.ID,
+				Rows:      int16(3),
+				Cols:      int16(3),
+				Mines:     int16(1),
+				Private:   false,
+			},
+			initialBoard: [][]int{
+				{-1, -2, -28},
+				{-1, -2, -2},
+				{-1, -1, -1},
+			},
+			// revealing an empty point should reveal the whole empty area and win the game
+			operation: Operation{
+				ID:  1,
+				Row: 2,
+				Col: 0,
+				Op:  algebra.OpReveal,
+			},
+			expectedConfirmation: OperationConfirmation{
+				Operation: Operation{
+					ID:      1,
+					Row:     2,
+					Col:     0,
+					Op:      algebra.OpReveal,
+					Applied: true,
+					Result: []OperationResult{
+						{Row: 2, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 1, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 1, Col: 1, MineProximity: 1, PointState: StateRevealed},
+						{Row: 2, Col: 1, MineProximity: 0, PointState: StateRevealed},
+						{Row: 1, Col: 2, MineProximity: 1, PointState: StateRevealed},
+						{Row: 2, Col: 2, MineProximity: 0, PointState: StateRevealed},
+						{Row: 0, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 0, Col: 1, MineProximity: 1, PointState: StateRevealed},
+					},
+				},
+				Status: Status{
+					Won:  true,
+					Rows: 3,
+					Cols: 3,
+					Board: [][]int{
+						{0, 1, -28},
+						{0, 1, 1},
+						{0, 0, 0},
+					},
+				},
+			},
+			expectedBoard: [][]int{
+				{0, 1, -28},
+				{0, 1, 1},
+				{0, 0, 0},
+			},
+		},
+		{
+			game: &models.Game{
+				CreatorID:       user.ID,
+				Rows:            int16(2),
+				Cols:            int16(3),
+				Mines:           int16(2),
+				Private:         false,
+				SafeFirstReveal: true,
+			},
+			initialBoard: [][]int{
+				{-28, -3, -2},
+				{-2, -3, -28},
+			},
+			// the first reveal should move the mine away to the only free point
+			operation: Operation{
+				ID:  1,
+				Row: 0,
+				Col: 0,
+				Op:  algebra.OpReveal,
+			},
+			expectedConfirmation: OperationConfirmation{
+				Operation: Operation{
+					ID:      1,
+					Row:     0,
+					Col:     0,
+					Op:      algebra.OpReveal,
+					Applied: true,
+					Result: []OperationResult{
+						{Row: 0, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 0, Col: 1, MineProximity: 2, PointState: StateRevealed},
+						{Row: 1, Col: 0, MineProximity: 0, PointState: StateRevealed},
+						{Row: 1, Col: 1, MineProximity: 2, PointState: StateRevealed},
+					},
+				},
+				Status: Status{
+					Won:  true,
+					Rows: 2,
+					Cols: 3,
+					Board: [][]int{
+						{0, 2, -28},
+						{0, 2, -28},
+					},
+				},
+			},
+			expectedBoard: [][]int{
+				{0, 2, -28},
+				{0, 2, -28},
+			},
+		},
 	}
 	assertGameTests(ctx, t, user, api, tests)
 }
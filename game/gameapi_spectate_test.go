@@ -0,0 +1,71 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestSpectateOnlyGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID:    user.ID,
+		Rows:         int16(2),
+		Cols:         int16(2),
+		Mines:        int16(1),
+		Private:      false,
+		SpectateOnly: true,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, anotherUser, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game as a spectator %v\n", err)
+	}
+	if !statefulGame.SpectateOnly {
+		t.Fatalf("expected game to be spectate only but was %v\n", statefulGame)
+	}
+	oper := Operation{
+		ID:     1,
+		GameID: game.ID,
+		Row:    0,
+		Col:    0,
+		Op:     algebra.OpReveal,
+	}
+	_, err = api.ApplyOperation(ctx, anotherUser, oper)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: ErrSpectator.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	_, err = api.Hint(ctx, anotherUser, game.ID)
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	confirmation, err := api.ApplyOperation(ctx, user, oper)
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if !confirmation.Operation.Applied {
+		t.Fatalf("expected the creator operation to be applied but was %v\n", confirmation)
+	}
+	statefulGame, err = api.RetrieveGame(ctx, anotherUser, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game as a spectator %v\n", err)
+	}
+	if !statefulGame.Board[0][0].Valid || statefulGame.Board[0][0].Int != 1 {
+		t.Fatalf("expected spectator to see the revealed point but was %v\n", statefulGame.Board)
+	}
+}
@@ -1,7 +1,17 @@
 package game
 
 import (
+	"math"
+	"math/rand"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/gamefile"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/javiercbk/minesweeper/topology"
+	"github.com/volatiletech/null"
 )
 
 func TestSiblingPoints(t *testing.T) {
@@ -213,7 +223,7 @@ func TestPlaceMine(t *testing.T) {
 			row: 0,
 			col: 0,
 			state: [][]int{
-				{-10, -2, -1, -1},
+				{-28, -2, -1, -1},
 				{-2, -2, -1, -1},
 				{-1, -1, -1, -1},
 				{-1, -1, -1, -1},
@@ -225,7 +235,7 @@ func TestPlaceMine(t *testing.T) {
 			col: 0,
 			state: [][]int{
 				{-2, -2, -1, -1},
-				{-10, -2, -1, -1},
+				{-28, -2, -1, -1},
 				{-2, -2, -1, -1},
 				{-1, -1, -1, -1},
 			},
@@ -237,7 +247,7 @@ func TestPlaceMine(t *testing.T) {
 			state: [][]int{
 				{-1, -1, -1, -1},
 				{-1, -2, -2, -2},
-				{-1, -2, -10, -2},
+				{-1, -2, -28, -2},
 				{-1, -2, -2, -2},
 			},
 		},
@@ -276,7 +286,7 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}},
 			state: [][]int{
-				{-10, -10, -2, -1},
+				{-28, -28, -2, -1},
 				{-3, -3, -2, -1},
 				{-1, -1, -1, -1},
 				{-1, -1, -1, -1},
@@ -286,7 +296,7 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 3}},
 			state: [][]int{
-				{-10, -2, -2, -10},
+				{-28, -2, -2, -28},
 				{-2, -2, -2, -2},
 				{-1, -1, -1, -1},
 				{-1, -1, -1, -1},
@@ -296,7 +306,7 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}},
 			state: [][]int{
-				{-10, -10, -2, -1},
+				{-28, -28, -2, -1},
 				{-3, -3, -2, -1},
 				{-1, -1, -1, -1},
 				{-1, -1, -1, -1},
@@ -306,7 +316,7 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}, {0, 2}},
 			state: [][]int{
-				{-10, -10, -10, -2},
+				{-28, -28, -28, -2},
 				{-3, -4, -3, -2},
 				{-1, -1, -1, -1},
 				{-1, -1, -1, -1},
@@ -316,8 +326,8 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}, {0, 2}, {1, 0}},
 			state: [][]int{
-				{-10, -10, -10, -2},
-				{-10, -5, -3, -2},
+				{-28, -28, -28, -2},
+				{-28, -5, -3, -2},
 				{-2, -2, -1, -1},
 				{-1, -1, -1, -1},
 			},
@@ -326,8 +336,8 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 2}},
 			state: [][]int{
-				{-10, -10, -10, -3},
-				{-10, -6, -10, -3},
+				{-28, -28, -28, -3},
+				{-28, -6, -28, -3},
 				{-2, -3, -2, -2},
 				{-1, -1, -1, -1},
 			},
@@ -336,9 +346,9 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}},
 			state: [][]int{
-				{-10, -10, -10, -3},
-				{-10, -7, -10, -3},
-				{-10, -4, -2, -2},
+				{-28, -28, -28, -3},
+				{-28, -7, -28, -3},
+				{-28, -4, -2, -2},
 				{-2, -2, -1, -1},
 			},
 		},
@@ -346,9 +356,9 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}, {2, 1}},
 			state: [][]int{
-				{-10, -10, -10, -3},
-				{-10, -8, -10, -3},
-				{-10, -10, -3, -2},
+				{-28, -28, -28, -3},
+				{-28, -8, -28, -3},
+				{-28, -28, -3, -2},
 				{-3, -3, -2, -1},
 			},
 		},
@@ -356,9 +366,9 @@ func TestPlaceSeveralMines(t *testing.T) {
 			b:     testBoardFactory(),
 			mines: []boardPoint{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}, {2, 1}, {2, 2}},
 			state: [][]int{
-				{-10, -10, -10, -3},
-				{-10, -9, -10, -4},
-				{-10, -10, -10, -3},
+				{-28, -28, -28, -3},
+				{-28, -9, -28, -4},
+				{-28, -28, -28, -3},
 				{-3, -4, -3, -2},
 			},
 		},
@@ -446,7 +456,7 @@ func TestNewBoard(t *testing.T) {
 			mineCount := test.mines
 			for r := range board {
 				for c := range board[r] {
-					if board[r][c] == -10 {
+					if board[r][c] == algebra.UnrevealedMine {
 						mineCount--
 					}
 				}
@@ -457,3 +467,974 @@ func TestNewBoard(t *testing.T) {
 		}
 	}
 }
+
+func TestRevealEmptyArea(t *testing.T) {
+	tests := []struct {
+		b        *board
+		row      int
+		col      int
+		revealed int
+		state    [][]int
+	}{
+		{
+			b: &board{
+				rows: 4,
+				cols: 4,
+				board: [][]int{
+					{-28, -2, -1, -1},
+					{-2, -2, -1, -1},
+					{-1, -1, -1, -1},
+					{-1, -1, -1, 0},
+				},
+			},
+			row:      3,
+			col:      3,
+			revealed: 14,
+			state: [][]int{
+				{-28, 1, 0, 0},
+				{1, 1, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+		},
+		{
+			b: &board{
+				rows: 4,
+				cols: 4,
+				board: [][]int{
+					{-28, -2, -1, -1},
+					{-2, -2, -29, -1},
+					{-1, -1, -1, -1},
+					{-1, -1, -1, 0},
+				},
+			},
+			// marked points should not be revealed
+			row:      3,
+			col:      3,
+			revealed: 13,
+			state: [][]int{
+				{-28, 1, 0, 0},
+				{1, 1, -29, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+		},
+		{
+			b: &board{
+				rows: 3,
+				cols: 3,
+				board: [][]int{
+					{-28, -2, -1},
+					{-3, -3, -2},
+					{-28, -2, 0},
+				},
+			},
+			// numbered points should stop the reveal
+			row:      2,
+			col:      2,
+			revealed: 3,
+			state: [][]int{
+				{-28, -2, -1},
+				{-3, 2, 1},
+				{-28, 1, 0},
+			},
+		},
+	}
+	for i, test := range tests {
+		revealed := test.b.revealEmptyArea(test.row, test.col)
+		if len(revealed) != test.revealed {
+			t.Fatalf("test %d, failed: expected %d points to be revealed but were %d\n", i, test.revealed, len(revealed))
+		}
+		for r := range test.b.board {
+			for c := range test.b.board[r] {
+				if test.b.board[r][c] != test.state[r][c] {
+					t.Fatalf("test %d, failed: expected row %d col %d to be %d but was %d\n", i, r, c, test.state[r][c], test.b.board[r][c])
+				}
+			}
+		}
+	}
+}
+
+func TestRevealedArea(t *testing.T) {
+	tests := []struct {
+		board [][]int
+		row   int
+		col   int
+	}{
+		{
+			board: [][]int{
+				{-28, -2, -1, -1},
+				{-2, -2, -1, -1},
+				{-1, -1, -1, -1},
+				{-1, -1, -1, 0},
+			},
+			row: 3,
+			col: 3,
+		},
+		{
+			// a marked point is not part of the area
+			board: [][]int{
+				{-28, -2, -1, -1},
+				{-2, -2, -29, -1},
+				{-1, -1, -1, -1},
+				{-1, -1, -1, 0},
+			},
+			row: 3,
+			col: 3,
+		},
+		{
+			board: [][]int{
+				{-28, -2, -1},
+				{-3, -3, -2},
+				{-28, -2, 0},
+			},
+			row: 2,
+			col: 2,
+		},
+	}
+	for i, test := range tests {
+		b := &board{
+			rows:  len(test.board),
+			cols:  len(test.board[0]),
+			board: test.board,
+		}
+		// the area found after the reveal must be the area that the reveal revealed
+		revealed := b.revealEmptyArea(test.row, test.col)
+		area := b.revealedArea(test.row, test.col)
+		if len(area) != len(revealed) {
+			t.Fatalf("test %d, failed: expected an area of %d points but was %d\n", i, len(revealed), len(area))
+		}
+		inArea := make(map[boardPoint]bool)
+		for _, p := range area {
+			inArea[p] = true
+		}
+		for _, p := range revealed {
+			if !inArea[p] {
+				t.Fatalf("test %d, failed: expected row %d col %d to be part of the area\n", i, p.row, p.col)
+			}
+		}
+	}
+}
+
+func TestMoveMinesAway(t *testing.T) {
+	tests := []struct {
+		b     *board
+		row   int
+		col   int
+		mines int
+		area  int
+	}{
+		{
+			b:     newMinedBoard(4, 4, []boardPoint{{0, 0}, {1, 1}, {3, 3}}),
+			row:   0,
+			col:   0,
+			mines: 3,
+			area:  4,
+		},
+		{
+			b:     newMinedBoard(5, 5, []boardPoint{{1, 1}, {1, 2}, {2, 1}, {2, 2}, {3, 3}, {0, 4}}),
+			row:   2,
+			col:   2,
+			mines: 6,
+			area:  9,
+		},
+		{
+			// a board too dense should clear the revealed point at least
+			b:     newMinedBoard(3, 3, []boardPoint{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {2, 0}}),
+			row:   1,
+			col:   1,
+			mines: 7,
+			area:  1,
+		},
+	}
+	for i, test := range tests {
+		random := rand.New(rand.NewSource(int64(i)))
+		test.b.moveMinesAway(test.row, test.col, random)
+		mines := make([]boardPoint, 0, test.mines)
+		for r := range test.b.board {
+			for c := range test.b.board[r] {
+				if test.b.board[r][c] == algebra.UnrevealedMine {
+					mines = append(mines, boardPoint{r, c})
+				}
+			}
+		}
+		if len(mines) != test.mines {
+			t.Fatalf("test %d, failed: expected mine count to be %d but was %d\n", i, test.mines, len(mines))
+		}
+		area := append([]boardPoint{{test.row, test.col}}, test.b.siblingPoints(test.row, test.col)...)
+		for _, p := range area[:test.area] {
+			if test.b.board[p.row][p.col] == algebra.UnrevealedMine {
+				t.Fatalf("test %d, failed: expected row %d col %d not to be a mine\n", i, p.row, p.col)
+			}
+		}
+		expected := newMinedBoard(test.b.rows, test.b.cols, mines)
+		for r := range test.b.board {
+			for c := range test.b.board[r] {
+				if test.b.board[r][c] != expected.board[r][c] {
+					t.Fatalf("test %d, failed: expected row %d col %d to be %d but was %d\n", i, r, c, expected.board[r][c], test.b.board[r][c])
+				}
+			}
+		}
+	}
+}
+
+func newMinedBoard(rows, cols int, mines []boardPoint) *board {
+	b := &board{
+		rows:  rows,
+		cols:  cols,
+		board: make([][]int, rows),
+	}
+	for r := range b.board {
+		b.board[r] = make([]int, cols)
+		for c := range b.board[r] {
+			b.board[r][c] = -1
+		}
+	}
+	for _, m := range mines {
+		b.placeMine(m.row, m.col)
+	}
+	return b
+}
+
+func TestChord(t *testing.T) {
+	tests := []struct {
+		b        *board
+		row      int
+		col      int
+		revealed int
+		state    [][]int
+	}{
+		{
+			b: &board{
+				rows: 3,
+				cols: 3,
+				board: [][]int{
+					{-57, -2, -28},
+					{-2, 2, -3},
+					{-1, -2, -28},
+				},
+			},
+			// marked siblings do not match the mine proximity
+			row:      1,
+			col:      1,
+			revealed: 0,
+			state: [][]int{
+				{-57, -2, -28},
+				{-2, 2, -3},
+				{-1, -2, -28},
+			},
+		},
+		{
+			b: &board{
+				rows: 3,
+				cols: 3,
+				board: [][]int{
+					{-30, -2, -84},
+					{-2, 2, -3},
+					{-1, -2, -84},
+				},
+			},
+			// suspicious marks are not revealed
+			row:      1,
+			col:      1,
+			revealed: 5,
+			state: [][]int{
+				{-30, 1, -84},
+				{1, 2, 2},
+				{0, 1, -84},
+			},
+		},
+		{
+			b: &board{
+				rows: 3,
+				cols: 3,
+				board: [][]int{
+					{-28, -2, -1},
+					{-2, -2, -1},
+					{-1, -1, -1},
+				},
+			},
+			// unrevealed points cannot be chorded
+			row:      1,
+			col:      1,
+			revealed: 0,
+			state: [][]int{
+				{-28, -2, -1},
+				{-2, -2, -1},
+				{-1, -1, -1},
+			},
+		},
+	}
+	for i, test := range tests {
+		revealed := test.b.chord(test.row, test.col)
+		if len(revealed) != test.revealed {
+			t.Fatalf("test %d, failed: expected %d points to be revealed but were %d\n", i, test.revealed, len(revealed))
+		}
+		for r := range test.b.board {
+			for c := range test.b.board[r] {
+				if test.b.board[r][c] != test.state[r][c] {
+					t.Fatalf("test %d, failed: expected row %d col %d to be %d but was %d\n", i, r, c, test.state[r][c], test.b.board[r][c])
+				}
+			}
+		}
+	}
+}
+
+func TestNewSeededBoard(t *testing.T) {
+	tests := []struct {
+		seed1            int64
+		seed2            int64
+		generatorVersion int
+		same             bool
+		err              error
+	}{
+		{
+			seed1:            42,
+			seed2:            42,
+			generatorVersion: GeneratorVersion,
+			same:             true,
+		},
+		{
+			seed1:            42,
+			seed2:            43,
+			generatorVersion: GeneratorVersion,
+			same:             false,
+		},
+		{
+			seed1:            42,
+			seed2:            42,
+			generatorVersion: GeneratorVersion + 1,
+			err:              ErrUnknownGeneratorVersion,
+		},
+	}
+	for i, test := range tests {
+		board1, err := NewSeededBoard(16, 30, 99, test.seed1, test.generatorVersion, topology.Square)
+		if err != test.err {
+			t.Fatalf("test %d, failed: expected err to be %v but was %v\n", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		board2, err := NewSeededBoard(16, 30, 99, test.seed2, test.generatorVersion, topology.Square)
+		if err != nil {
+			t.Fatalf("test %d, failed: unexpected error %v\n", i, err)
+		}
+		same := true
+		for r := range board1 {
+			for c := range board1[r] {
+				if board1[r][c] != board2[r][c] {
+					same = false
+				}
+			}
+		}
+		if same != test.same {
+			t.Fatalf("test %d, failed: expected boards to be the same %v but was %v\n", i, test.same, same)
+		}
+	}
+}
+
+func TestSolvableFrom(t *testing.T) {
+	tests := []struct {
+		b        *board
+		row      int
+		col      int
+		solvable bool
+	}{
+		{
+			b:        newMinedBoard(3, 3, []boardPoint{{0, 0}}),
+			row:      2,
+			col:      2,
+			solvable: true,
+		},
+		{
+			// revealing a mine is not solving the board
+			b:        newMinedBoard(3, 3, []boardPoint{{0, 0}}),
+			row:      0,
+			col:      0,
+			solvable: false,
+		},
+		{
+			// the last two points are a 50/50 guess
+			b:        newMinedBoard(2, 5, []boardPoint{{0, 0}}),
+			row:      1,
+			col:      4,
+			solvable: false,
+		},
+		{
+			// the mine count tells that both remaining points are mines
+			b:        newMinedBoard(2, 5, []boardPoint{{0, 0}, {1, 0}}),
+			row:      1,
+			col:      4,
+			solvable: true,
+		},
+		{
+			// the 1-2-1 pattern needs the subset rule
+			b:        newMinedBoard(3, 5, []boardPoint{{0, 1}, {0, 3}}),
+			row:      2,
+			col:      2,
+			solvable: true,
+		},
+	}
+	for i, test := range tests {
+		test.b.mines = 0
+		for r := range test.b.board {
+			for c := range test.b.board[r] {
+				if test.b.board[r][c] == algebra.UnrevealedMine {
+					test.b.mines++
+				}
+			}
+		}
+		solvable := test.b.solvableFrom(test.row, test.col)
+		if solvable != test.solvable {
+			t.Fatalf("test %d, failed: expected solvable to be %v but was %v\n", i, test.solvable, solvable)
+		}
+	}
+}
+
+func TestNewNoGuessBoard(t *testing.T) {
+	tests := []struct {
+		rows  int
+		cols  int
+		mines int
+		row   int
+		col   int
+		err   error
+	}{
+		{
+			rows:  9,
+			cols:  9,
+			mines: 10,
+			row:   4,
+			col:   4,
+		},
+		{
+			rows:  16,
+			cols:  30,
+			mines: 99,
+			row:   0,
+			col:   0,
+		},
+		{
+			rows:  5,
+			cols:  5,
+			mines: 20,
+			row:   2,
+			col:   2,
+			err:   ErrNoGuessBoardNotFound,
+		},
+		{
+			rows:  5,
+			cols:  5,
+			mines: 5,
+			row:   5,
+			col:   2,
+			err:   ErrInvalidRowCols,
+		},
+	}
+	for i, test := range tests {
+		generated, err := NewNoGuessBoard(test.rows, test.cols, test.mines, int64(i), GeneratorVersion, topology.Square, test.row, test.col)
+		if err != test.err {
+			t.Fatalf("test %d, failed: expected err to be %v but was %v\n", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if generated[test.row][test.col] != -1 {
+			t.Fatalf("test %d, failed: expected first reveal to be empty but was %d\n", i, generated[test.row][test.col])
+		}
+		b := &board{
+			rows:  test.rows,
+			cols:  test.cols,
+			mines: test.mines,
+			board: generated,
+		}
+		if !b.solvableFrom(test.row, test.col) {
+			t.Fatalf("test %d, failed: expected board to be solvable without guessing\n", i)
+		}
+	}
+}
+
+func TestNoGuessFirstReveal(t *testing.T) {
+	b := newMinedBoard(9, 9, []boardPoint{{0, 0}, {0, 8}, {1, 4}, {3, 3}, {4, 4}, {4, 5}, {5, 5}, {6, 1}, {8, 0}, {8, 8}})
+	b.mines = 10
+	noGuessBoard, err := noGuessFirstReveal(b.rows, b.cols, b.mines, b.neighbours, 1, 4, 4)
+	if err != nil {
+		t.Fatalf("error generating no guess board %v\n", err)
+	}
+	again, err := noGuessFirstReveal(b.rows, b.cols, b.mines, b.neighbours, 1, 4, 4)
+	if err != nil || !reflect.DeepEqual(again.board, noGuessBoard.board) {
+		t.Fatalf("expected the no guess board to be reproducible\n")
+	}
+	changed := b.replaceMines(noGuessBoard)
+	if len(changed) == 0 {
+		t.Fatalf("expected the mines around the first reveal to be moved\n")
+	}
+	if !b.solvableFrom(4, 4) {
+		t.Fatalf("expected board to be solvable without guessing\n")
+	}
+	_, err = noGuessFirstReveal(5, 5, 20, topology.Square, 1, 2, 2)
+	if err != ErrNoGuessBoardNotFound {
+		t.Fatalf("expected err to be %v but was %v\n", ErrNoGuessBoardNotFound, err)
+	}
+}
+
+func TestNewHint(t *testing.T) {
+	tests := []struct {
+		board    [][]int
+		mines    int
+		expected Hint
+	}{
+		{
+			// the zero proves its siblings are safe
+			board: [][]int{
+				{0, -1, -1},
+				{-1, -1, -1},
+				{-1, -1, -28},
+			},
+			mines:    1,
+			expected: Hint{Action: HintReveal, Row: 0, Col: 1},
+		},
+		{
+			// the only mine is next to the one
+			board: [][]int{
+				{1, -28, -1},
+			},
+			mines:    1,
+			expected: Hint{Action: HintReveal, Row: 0, Col: 2},
+		},
+		{
+			board: [][]int{
+				{1, -28},
+				{1, 1},
+			},
+			mines:    1,
+			expected: Hint{Action: HintMark, Row: 0, Col: 1},
+		},
+		{
+			// nothing can be deduced, the points away from the one are less risky
+			board: [][]int{
+				{1, -2, -1},
+				{-2, -28, -2},
+				{-1, -2, -28},
+			},
+			mines: 2,
+			expected: Hint{
+				Action: HintGuess,
+				Row:    0,
+				Col:    2,
+				Risk:   0.2,
+			},
+		},
+	}
+	for i, test := range tests {
+		hint, err := newHint(test.board, test.mines, topology.Square)
+		if err != nil {
+			t.Fatalf("test %d, failed: unexpected error %v\n", i, err)
+		}
+		if hint.Action != test.expected.Action || hint.Row != test.expected.Row || hint.Col != test.expected.Col || math.Abs(hint.Risk-test.expected.Risk) > 1e-9 {
+			t.Fatalf("test %d, failed: expected hint %v but was %v\n", i, test.expected, hint)
+		}
+	}
+}
+
+func TestNewHexBoard(t *testing.T) {
+	generated, err := NewSeededBoard(9, 9, 10, 42, GeneratorVersion, topology.Hex)
+	if err != nil {
+		t.Fatalf("error creating hex board: %v\n", err)
+	}
+	for r := range generated {
+		for c := range generated[r] {
+			if generated[r][c] == algebra.UnrevealedMine {
+				continue
+			}
+			mines := 0
+			for _, p := range topology.Hex(9, 9, r, c, nil) {
+				if generated[p.Row][p.Col] == algebra.UnrevealedMine {
+					mines++
+				}
+			}
+			if generated[r][c] != -mines-1 {
+				t.Fatalf("expected row %d, col %d to be %d but was %d\n", r, c, -mines-1, generated[r][c])
+			}
+		}
+	}
+}
+
+func TestHexRevealEmptyArea(t *testing.T) {
+	// on a hex board the odd rows are shifted to the right, so (1, 2) does not touch the mine at (0, 1)
+	b := &board{
+		rows: 3,
+		cols: 3,
+		board: [][]int{
+			{-2, -28, -2},
+			{-2, -2, -1},
+			{-1, -1, 0},
+		},
+		neighbours: topology.Hex,
+	}
+	revealed := b.revealEmptyArea(2, 2)
+	expectedBoard := [][]int{
+		{-2, -28, 1},
+		{1, 1, 0},
+		{0, 0, 0},
+	}
+	for r := range expectedBoard {
+		for c := range expectedBoard[r] {
+			if b.board[r][c] != expectedBoard[r][c] {
+				t.Fatalf("expected row %d, col %d to be %d but was %d\n", r, c, expectedBoard[r][c], b.board[r][c])
+			}
+		}
+	}
+	if len(revealed) != 6 {
+		t.Fatalf("expected 6 revealed points but got %d\n", len(revealed))
+	}
+}
+
+func TestNewHexNoGuessBoard(t *testing.T) {
+	generated, err := NewNoGuessBoard(16, 16, 30, 7, GeneratorVersion, topology.Hex, 8, 8)
+	if err != nil {
+		t.Fatalf("error creating hex no guess board: %v\n", err)
+	}
+	b := &board{
+		rows:       16,
+		cols:       16,
+		mines:      30,
+		board:      generated,
+		neighbours: topology.Hex,
+	}
+	if !b.solvableFrom(8, 8) {
+		t.Fatalf("expected hex board to be solvable without guessing\n")
+	}
+}
+
+func TestNewWrapBoard(t *testing.T) {
+	generated, err := NewSeededBoard(9, 9, 10, 42, GeneratorVersion, topology.SquareTorus)
+	if err != nil {
+		t.Fatalf("error creating wrap board: %v\n", err)
+	}
+	for r := range generated {
+		for c := range generated[r] {
+			if generated[r][c] == algebra.UnrevealedMine {
+				continue
+			}
+			mines := 0
+			for _, p := range topology.SquareTorus(9, 9, r, c, nil) {
+				if generated[p.Row][p.Col] == algebra.UnrevealedMine {
+					mines++
+				}
+			}
+			if generated[r][c] != -mines-1 {
+				t.Fatalf("expected row %d, col %d to be %d but was %d\n", r, c, -mines-1, generated[r][c])
+			}
+		}
+	}
+}
+
+func TestWrapRevealEmptyArea(t *testing.T) {
+	// the empty points of the last row and column reach the first row and column across the edges, so every
+	// point but the mine is revealed
+	b := newEmptyBoard(4, 4, 1, topology.SquareTorus)
+	b.placeMine(1, 1)
+	revealed := b.revealEmptyArea(3, 3)
+	if len(revealed) != 15 {
+		t.Fatalf("expected 15 revealed points but got %d\n", len(revealed))
+	}
+	if b.board[0][0] != 1 || b.board[3][3] != 0 || b.board[1][1] != algebra.UnrevealedMine {
+		t.Fatalf("expected the board to be revealed around the mine but was %v\n", b.board)
+	}
+}
+
+func TestNewLayeredBoard(t *testing.T) {
+	_, err := NewLayeredBoard(11, 5, 5, 10, 42, GeneratorVersion)
+	if err != ErrInvalidLayers {
+		t.Fatalf("expected err to be %v but was %v\n", ErrInvalidLayers, err)
+	}
+	_, err = NewLayeredBoard(2, 2, 2, 8, 42, GeneratorVersion)
+	if err != ErrTooManyMines {
+		t.Fatalf("expected err to be %v but was %v\n", ErrTooManyMines, err)
+	}
+	_, err = NewLayeredBoard(10, 100, 100, math.MaxInt16+1, 42, GeneratorVersion)
+	if err != ErrTooManyMines {
+		t.Fatalf("expected err to be %v but was %v\n", ErrTooManyMines, err)
+	}
+	generated, err := NewLayeredBoard(3, 5, 5, 10, 42, GeneratorVersion)
+	if err != nil {
+		t.Fatalf("error creating layered board: %v\n", err)
+	}
+	if len(generated) != 15 {
+		t.Fatalf("expected the 3 layers of 5 rows to be stacked in 15 rows but got %d\n", len(generated))
+	}
+	cube := topology.Cube(3)
+	mines := 0
+	for r := range generated {
+		for c := range generated[r] {
+			if generated[r][c] == algebra.UnrevealedMine {
+				mines++
+				continue
+			}
+			siblingMines := 0
+			for _, p := range cube(15, 5, r, c, nil) {
+				if generated[p.Row][p.Col] == algebra.UnrevealedMine {
+					siblingMines++
+				}
+			}
+			if generated[r][c] != -siblingMines-1 {
+				t.Fatalf("expected row %d, col %d to be %d but was %d\n", r, c, -siblingMines-1, generated[r][c])
+			}
+		}
+	}
+	if mines != 10 {
+		t.Fatalf("expected 10 mines but got %d\n", mines)
+	}
+}
+
+func TestLayeredMineProximity(t *testing.T) {
+	// every point of a cube of 3 layers of 3x3 points is a mine but the center, which touches the other 26
+	b := newEmptyBoard(9, 3, 26, topology.Cube(3))
+	b.layers = 3
+	center := b.stackedRow(1, 1)
+	for r := range b.board {
+		for c := range b.board[r] {
+			if r != center || c != 1 {
+				b.placeMine(r, c)
+			}
+		}
+	}
+	if b.board[center][1] != -algebra.MaxMineProximity-1 {
+		t.Fatalf("expected the center to have %d mines around but was %d\n", algebra.MaxMineProximity, b.board[center][1])
+	}
+	if proximityToState(b.board[center][1]) != StateNotRevealed || isMine(b.board[center][1]) {
+		t.Fatalf("expected the center to be an unrevealed point without a mine but was %d\n", b.board[center][1])
+	}
+	reveal, _ := algebra.NewLayeredOperation(algebra.OpReveal, 1, 1, 1)
+	revealed, err := reveal.Exec(b.board[center][1])
+	if err != nil || revealed != algebra.MaxMineProximity {
+		t.Fatalf("expected the center to be revealed with %d mines around but was %d, %v\n", algebra.MaxMineProximity, revealed, err)
+	}
+	b.board[center][1] = revealed
+	// marking a mine below the center keeps its count, and a chord needs the 26 of them marked
+	mark, _ := algebra.NewLayeredOperation(algebra.OpMark, 2, 1, 1)
+	below := b.stackedRow(2, 1)
+	for i := 0; i < 2; i++ {
+		b.board[below][1], err = mark.Exec(b.board[below][1])
+		if err != nil {
+			t.Fatalf("error marking the point below the center %v\n", err)
+		}
+	}
+	if !isMine(b.board[below][1]) || proximityToState(b.board[below][1]) != StateMarkedMine {
+		t.Fatalf("expected the point below the center to be a marked mine but was %d\n", b.board[below][1])
+	}
+	if chorded := b.chord(center, 1); len(chorded) != 0 {
+		t.Fatalf("expected the chord not to reveal anything but revealed %v\n", chorded)
+	}
+}
+
+func TestLayeredRevealEmptyArea(t *testing.T) {
+	// 2 layers of 3x3 points with a mine in the corner of the first layer, the empty area reaches every point but
+	// the one below the mine, which is only surrounded by points next to the mine
+	b := newEmptyBoard(6, 3, 1, topology.Cube(2))
+	b.layers = 2
+	b.placeMine(0, 0)
+	row := b.stackedRow(1, 2)
+	b.board[row][2] = 0
+	revealed := b.revealEmptyArea(row, 2)
+	if len(revealed) != 15 {
+		t.Fatalf("expected 15 revealed points but got %d\n", len(revealed))
+	}
+	if b.board[b.stackedRow(1, 0)][0] != -2 {
+		t.Fatalf("expected the point below the mine to be unrevealed but was %d\n", b.board[b.stackedRow(1, 0)][0])
+	}
+	for _, p := range revealed {
+		if p.row != 4 || p.col != 0 {
+			continue
+		}
+		result := b.revealedResult(p)
+		if result.Layer != 1 || result.Row != 1 || result.Col != 0 || result.MineProximity != 1 {
+			t.Fatalf("expected layer 1, row 1, col 0 to be revealed with 1 mine around but got %v\n", result)
+		}
+		return
+	}
+	t.Fatalf("expected layer 1, row 1, col 0 to be revealed\n")
+}
+
+func TestNewLayoutBoard(t *testing.T) {
+	testTable := []struct {
+		layout   []LayoutMine
+		expected [][]int
+		err      error
+	}{
+		{
+			layout: []LayoutMine{{Row: 0, Col: 1}, {Row: 2, Col: 2}},
+			expected: [][]int{
+				{-2, -28, -2},
+				{-2, -3, -3},
+				{-1, -2, -28},
+			},
+		},
+		{
+			layout: []LayoutMine{{Row: 0, Col: 1}, {Row: 3, Col: 0}},
+			err:    ErrInvalidLayout,
+		},
+		{
+			layout: []LayoutMine{{Row: 0, Col: 1}, {Layer: 1, Row: 0, Col: 0}},
+			err:    ErrInvalidLayout,
+		},
+		{
+			layout: []LayoutMine{{Row: 0, Col: 1}, {Row: 0, Col: 1}},
+			err:    ErrInvalidLayout,
+		},
+		{
+			layout: []LayoutMine{},
+			err:    ErrNoneMines,
+		},
+	}
+	for i, test := range testTable {
+		generated, err := NewLayoutBoard(1, 3, 3, test.layout, topology.Square)
+		if err != test.err {
+			t.Fatalf("test %d failed: expected err to be %v but was %v\n", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(generated, test.expected) {
+			t.Fatalf("test %d failed: expected board %v but was %v\n", i, test.expected, generated)
+		}
+	}
+}
+
+func TestElapsedMillis(t *testing.T) {
+	start := time.Date(2019, time.March, 1, 10, 0, 0, 0, time.UTC)
+	now := start.Add(time.Minute)
+	testTable := []struct {
+		startedAt    null.Time
+		finishedAt   null.Time
+		pausedAt     null.Time
+		pausedMillis int64
+		expected     int64
+	}{
+		{
+			expected: 0,
+		},
+		{
+			startedAt: null.TimeFrom(start),
+			expected:  60000,
+		},
+		{
+			startedAt:    null.TimeFrom(start),
+			pausedMillis: 15000,
+			expected:     45000,
+		},
+		{
+			startedAt:    null.TimeFrom(start),
+			pausedAt:     null.TimeFrom(start.Add(20 * time.Second)),
+			pausedMillis: 5000,
+			expected:     15000,
+		},
+		{
+			startedAt:  null.TimeFrom(start),
+			finishedAt: null.TimeFrom(start.Add(1500 * time.Millisecond)),
+			expected:   1500,
+		},
+		{
+			startedAt:    null.TimeFrom(start),
+			pausedMillis: 120000,
+			expected:     0,
+		},
+	}
+	for i, test := range testTable {
+		elapsed := elapsedMillis(test.startedAt, test.finishedAt, test.pausedAt, test.pausedMillis, now)
+		if elapsed != test.expected {
+			t.Fatalf("test %d failed: expected elapsed to be %d but was %d\n", i, test.expected, elapsed)
+		}
+	}
+}
+
+func TestUndoTarget(t *testing.T) {
+	reveal := &models.GameOperation{OperationID: 1, Operation: models.MineOperationReveal}
+	mark := &models.GameOperation{OperationID: 2, Operation: models.MineOperationMark}
+	hint := &models.GameOperation{OperationID: 3, Operation: models.MineOperationHint}
+	undo := &models.GameOperation{OperationID: 4, Operation: models.MineOperationUndo}
+	testTable := []struct {
+		operations models.GameOperationSlice
+		expected   *models.GameOperation
+	}{
+		{
+			operations: models.GameOperationSlice{},
+			expected:   nil,
+		},
+		{
+			operations: models.GameOperationSlice{hint, mark, reveal},
+			expected:   mark,
+		},
+		{
+			operations: models.GameOperationSlice{undo, hint, mark, reveal},
+			expected:   reveal,
+		},
+		{
+			operations: models.GameOperationSlice{undo, undo, mark, reveal},
+			expected:   nil,
+		},
+	}
+	for i, test := range testTable {
+		target := undoTarget(test.operations)
+		if target != test.expected {
+			t.Fatalf("test %d failed: expected target to be %v but was %v\n", i, test.expected, target)
+		}
+	}
+}
+
+func TestValidateImportedBoard(t *testing.T) {
+	tests := []struct {
+		change func(f *gamefile.File)
+		err    error
+	}{
+		{
+			change: func(f *gamefile.File) {},
+		},
+		{
+			change: func(f *gamefile.File) { f.Board[0][1] = 0 },
+			err:    ErrMineProximityMismatch,
+		},
+		{
+			change: func(f *gamefile.File) {
+				f.Board[0][1] = algebra.UnrevealedMine
+				f.Board[1][1] = -1 - 2*algebra.MarkOffset
+			},
+			err: ErrMineProximityMismatch,
+		},
+		{
+			change: func(f *gamefile.File) { f.Operations[0].MineProximity = 2 },
+			err:    ErrMineProximityMismatch,
+		},
+		{
+			change: func(f *gamefile.File) { f.Operations[1].MineProximity = algebra.SuspectMine },
+			err:    ErrMineProximityMismatch,
+		},
+		{
+			change: func(f *gamefile.File) { f.Operations[2].MineProximity = algebra.UnrevealedMine },
+		},
+	}
+	for i, test := range tests {
+		f := gamefile.New()
+		f.Layers = 1
+		f.Rows = 2
+		f.Cols = 2
+		f.Mines = 1
+		f.Board = [][]int{
+			{1, -2},
+			{-2 - algebra.MarkOffset, algebra.MarkedMine},
+		}
+		f.Operations = []gamefile.Operation{
+			{ID: 1, Op: models.MineOperationReveal, Row: 0, Col: 0, MineProximity: 1},
+			{ID: 2, Op: models.MineOperationMark, Row: 1, Col: 0, MineProximity: -2 - algebra.MarkOffset},
+			{ID: 3, Op: models.MineOperationHint, Row: 1, Col: 1, MineProximity: hintMineProximity},
+			{ID: 4, Op: models.MineOperationMark, Row: 1, Col: 1, MineProximity: algebra.SuspectMine},
+			{ID: 5, Op: models.MineOperationMark, Row: 1, Col: 1, MineProximity: algebra.MarkedMine},
+		}
+		test.change(&f)
+		err := validateImportedBoard(f, topology.Square)
+		if err != test.err {
+			t.Fatalf("test %d failed: expected err to be %v but was %v\n", i, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+)
+
+func TestTurnBasedGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	err := api.CreateGame(ctx, user, &ProspectGame{Rows: 3, Cols: 3, Mines: 1, Casual: true, TurnBased: true})
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrTurnBasedNotShared.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(3),
+		Mines:     int16(1),
+		Private:   false,
+		TurnBased: true,
+	}
+	err = api.storeGameBoard(ctx, user, game, [][]int{
+		{-1, -2, -28},
+		{-1, -2, -2},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.ApplyOperation(ctx, anotherUser, Operation{ID: 1, GameID: game.ID, Row: 1, Col: 2, Op: algebra.OpReveal})
+	expectedErr = response.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: ErrNotJoined.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	err = api.JoinGame(ctx, anotherUser, game.ID)
+	if err != nil {
+		t.Fatalf("error joining game %v\n", err)
+	}
+	err = api.JoinGame(ctx, anotherUser, game.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrAlreadyJoined.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	_, err = api.ApplyOperation(ctx, anotherUser, Operation{ID: 1, GameID: game.ID, Row: 1, Col: 2, Op: algebra.OpReveal})
+	expectedErr = response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrNotYourTurn.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	_, err = api.ApplyOperation(ctx, user, Operation{ID: 1, GameID: game.ID, Row: 1, Col: 2, Op: algebra.OpReveal})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if len(statefulGame.TurnOrder) != 2 || statefulGame.TurnOrder[0].ID != user.ID || statefulGame.TurnOrder[1].ID != anotherUser.ID {
+		t.Fatalf("expected the creator to play before the player that joined but turn order was %v\n", statefulGame.TurnOrder)
+	}
+	if statefulGame.CurrentPlayer == nil || statefulGame.CurrentPlayer.ID != anotherUser.ID {
+		t.Fatalf("expected the turn of player %d but was %v\n", anotherUser.ID, statefulGame.CurrentPlayer)
+	}
+	_, err = api.ApplyOperation(ctx, user, Operation{ID: 2, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal})
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	_, err = api.ApplyOperation(ctx, anotherUser, Operation{ID: 2, GameID: game.ID, Row: 0, Col: 0, Op: algebra.OpReveal})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+}
+
+func TestCurrentTurn(t *testing.T) {
+	startedAt := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	testTable := []struct {
+		position          int
+		players           int
+		limit             time.Duration
+		elapsed           time.Duration
+		expectedPosition  int
+		expectedStartedAt time.Time
+	}{
+		{position: 0, players: 3, limit: 0, elapsed: time.Hour, expectedPosition: 0, expectedStartedAt: startedAt},
+		{position: 1, players: 3, limit: time.Minute, elapsed: 30 * time.Second, expectedPosition: 1, expectedStartedAt: startedAt},
+		{position: 1, players: 3, limit: time.Minute, elapsed: 90 * time.Second, expectedPosition: 2, expectedStartedAt: startedAt.Add(time.Minute)},
+		{position: 2, players: 3, limit: time.Minute, elapsed: 5 * time.Minute, expectedPosition: 1, expectedStartedAt: startedAt.Add(5 * time.Minute)},
+		{position: 0, players: 1, limit: time.Minute, elapsed: 150 * time.Second, expectedPosition: 0, expectedStartedAt: startedAt.Add(2 * time.Minute)},
+		{position: 4, players: 2, limit: 0, elapsed: 0, expectedPosition: 0, expectedStartedAt: startedAt},
+	}
+	for i, test := range testTable {
+		position, turnStartedAt := currentTurn(test.position, test.players, startedAt, test.limit, startedAt.Add(test.elapsed))
+		if position != test.expectedPosition {
+			t.Fatalf("test %d failed: expected position %d but got %d\n", i, test.expectedPosition, position)
+		}
+		if !turnStartedAt.Equal(test.expectedStartedAt) {
+			t.Fatalf("test %d failed: expected the turn to start at %v but got %v\n", i, test.expectedStartedAt, turnStartedAt)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+func TestUndoOperation(t *testing.T) {
+	ctx := context.Background()
+	api, user, anotherUser := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(3),
+		Cols:      int16(3),
+		Mines:     int16(1),
+		Private:   false,
+		Casual:    true,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-1, -1, -1},
+		{-2, -2, -1},
+		{-28, -2, -1},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.UndoOperation(ctx, user, game.ID)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrNothingToUndo.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	confirmation, err := api.ApplyOperation(ctx, user, Operation{
+		ID:     1,
+		GameID: game.ID,
+		Row:    2,
+		Col:    0,
+		Op:     algebra.OpReveal,
+	})
+	if err != nil {
+		t.Fatalf("error applying operation %v\n", err)
+	}
+	if !confirmation.Status.Lost {
+		t.Fatalf("expected game to be lost but was %v\n", confirmation.Status)
+	}
+	_, err = api.UndoOperation(ctx, anotherUser, game.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrUndoNotAllowed.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+	confirmation, err = api.UndoOperation(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error undoing operation %v\n", err)
+	}
+	expectedResult := OperationResult{Row: 2, Col: 0, PointState: StateNotRevealed}
+	if confirmation.Operation.ID != 2 || len(confirmation.Operation.Result) != 1 || confirmation.Operation.Result[0] != expectedResult {
+		t.Fatalf("expected the mine to be hidden again by operation 2 but was %v\n", confirmation.Operation)
+	}
+	point, err := models.GameBoardPoints(qm.Where("game_id = ? AND row = ? AND col = ?", game.ID, 2, 0)).One(ctx, api.db)
+	if err != nil {
+		t.Fatalf("error retrieving game point %v\n", err)
+	}
+	if point.MineProximity != algebra.UnrevealedMine {
+		t.Fatalf("expected point to be an unrevealed mine but was %d\n", point.MineProximity)
+	}
+	statefulGame, err := api.RetrieveGame(ctx, user, game.ID)
+	if err != nil {
+		t.Fatalf("error retrieving game %v\n", err)
+	}
+	if statefulGame.FinishedAt.Valid || statefulGame.Undos != 1 {
+		t.Fatalf("expected the game to be reopened with 1 undo but was %v\n", statefulGame)
+	}
+	undoneOperation, err := models.GameOperations(qm.Where("game_id = ? AND operation_id = ?", game.ID, 1)).One(ctx, api.db)
+	if err != nil {
+		t.Fatalf("error retrieving undone operation %v\n", err)
+	}
+	if undoneOperation.Operation != models.MineOperationReveal {
+		t.Fatalf("expected the undone operation to be kept but was %v\n", undoneOperation)
+	}
+	// the reveal was already undone
+	_, err = api.UndoOperation(ctx, user, game.ID)
+	expectedErr = response.HTTPError{
+		Code:    http.StatusConflict,
+		Message: ErrNothingToUndo.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+}
+
+func TestUndoRankedGame(t *testing.T) {
+	ctx := context.Background()
+	api, user, _ := setUp(ctx, t, username)
+	game := &models.Game{
+		CreatorID: user.ID,
+		Rows:      int16(2),
+		Cols:      int16(2),
+		Mines:     int16(1),
+		Private:   false,
+	}
+	err := api.storeGameBoard(ctx, user, game, [][]int{
+		{-2, -2},
+		{-2, -28},
+	})
+	if err != nil {
+		t.Fatalf("error creating board %v\n", err)
+	}
+	_, err = api.UndoOperation(ctx, user, game.ID)
+	expectedErr := response.HTTPError{
+		Code:    http.StatusBadRequest,
+		Message: ErrGameNotCasual.Error(),
+	}
+	if err != expectedErr {
+		t.Fatalf("expected err to be %v, but was %v\n", expectedErr, err)
+	}
+}
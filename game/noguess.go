@@ -0,0 +1,117 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/javiercbk/minesweeper/solver"
+	"github.com/javiercbk/minesweeper/topology"
+	"github.com/volatiletech/null"
+)
+
+// noGuessAttempts is the amount of boards generated looking for one that can be solved without guessing
+const noGuessAttempts = 100
+
+// noGuessSeeds is the amount of seeds derived from the game seed used to look for a no guess board at the first reveal
+const noGuessSeeds = 10
+
+// ErrNoGuessBoardNotFound is returned when a board that can be solved without guessing could not be found
+var ErrNoGuessBoardNotFound = errors.New("could not find a board that can be solved without guessing, try with less mines")
+
+// ErrNoGuessFirstReveal is returned when a no guess board could not be found around the first reveal of a game
+var ErrNoGuessFirstReveal = errors.New("could not find a board that can be solved without guessing from this point, try revealing another point")
+
+// noGuessFirstReveal looks for a board that can be solved without guessing from the given point. The seeds derived
+// from the game seed are tried in order, so the board is reproducible
+func noGuessFirstReveal(rows, cols, mines int, neighbours topology.Neighbours, seed int64, row, col int) (*board, error) {
+	for i := int64(0); i < noGuessSeeds; i++ {
+		noGuessBoard, err := newNoGuessBoard(rows, cols, mines, neighbours, rand.New(rand.NewSource(seed+i)), row, col)
+		if err == nil {
+			return noGuessBoard, nil
+		}
+	}
+	return nil, ErrNoGuessBoardNotFound
+}
+
+// NewNoGuessBoard creates a minesweeper board that can be solved without guessing when the first reveal is the
+// given point. It is the board the first reveal of a no guess game on that point gets, so it is always the same for
+// the same seed, size, mines, generator version and point.
+func NewNoGuessBoard(rows, cols, mines int, seed int64, generatorVersion int, neighbours topology.Neighbours, row, col int) ([][]int, error) {
+	var initializedBoard [][]int
+	err := validateBoard(1, rows, cols, mines, generatorVersion)
+	if err != nil {
+		return initializedBoard, err
+	}
+	if row < 0 || row >= rows || col < 0 || col >= cols {
+		return initializedBoard, ErrInvalidRowCols
+	}
+	b, err := noGuessFirstReveal(rows, cols, mines, neighbours, seed, row, col)
+	if err != nil {
+		return initializedBoard, err
+	}
+	return b.board, nil
+}
+
+// newNoGuessBoard places the mines outside of the area of the given point until the board can be solved without
+// guessing or the attempts are exhausted
+func newNoGuessBoard(rows, cols, mines int, neighbours topology.Neighbours, random *rand.Rand, row, col int) (*board, error) {
+	b := newEmptyBoard(rows, cols, mines, neighbours)
+	area := append([]boardPoint{{row: row, col: col}}, b.siblingPoints(row, col)...)
+	inArea := make(map[boardPoint]bool, len(area))
+	for _, p := range area {
+		inArea[p] = true
+	}
+	candidates := make([]boardPoint, 0, rows*cols)
+	for r := range b.board {
+		for c := range b.board[r] {
+			p := boardPoint{row: r, col: c}
+			if !inArea[p] {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+	if len(candidates) < mines {
+		return b, ErrNoGuessBoardNotFound
+	}
+	for attempt := 0; attempt < noGuessAttempts; attempt++ {
+		b = newEmptyBoard(rows, cols, mines, neighbours)
+		random.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		for _, p := range candidates[:mines] {
+			b.placeMine(p.row, p.col)
+		}
+		if b.solvableFrom(row, col) {
+			return b, nil
+		}
+	}
+	return b, ErrNoGuessBoardNotFound
+}
+
+// solvableFrom returns true if all the points without a mine can be revealed without guessing after revealing
+// the given point. The solver plays the board revealing the points it deduces are safe.
+func (b *board) solvableFrom(row, col int) bool {
+	if isMine(b.board[row][col]) {
+		return false
+	}
+	visible := make([][]null.Int, b.rows)
+	for r := range visible {
+		visible[r] = make([]null.Int, b.cols)
+	}
+	s, err := solver.NewWithTopology(visible, nil, b.mines, b.neighbourFunc())
+	if err != nil {
+		return false
+	}
+	revealed := 0
+	reveal := func(row, col int) int {
+		revealed++
+		mp := b.board[row][col]
+		return -(mp - markOffset(mp)) - 1
+	}
+	err = s.Reveal(row, col, reveal(row, col))
+	if err != nil {
+		return false
+	}
+	_, err = s.Play(reveal)
+	return err == nil && revealed == b.rows*b.cols-b.mines
+}
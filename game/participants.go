@@ -0,0 +1,241 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	extErrors "github.com/pkg/errors"
+
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/http/security"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/lib/pq"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// uniqueGameParticipantConstaintName is the constraint that ensures that a player is invited once to a game
+const uniqueGameParticipantConstaintName = "idx_game_participant"
+
+// ErrSpectator is returned when a player that can only watch a game attempts to play it
+var ErrSpectator = errors.New("the game can only be watched")
+
+// ErrNotGameCreator is returned when a player that did not create a game attempts to resign it, delete it or
+// invite players to it
+var ErrNotGameCreator = errors.New("only the creator of the game can do that")
+
+// ErrPlayerNotExists is returned when inviting a player that does not exist
+var ErrPlayerNotExists = errors.New("the player does not exist")
+
+// ErrAlreadyParticipant is returned when inviting a player that was already invited to the game
+var ErrAlreadyParticipant = errors.New("the player was already invited to the game")
+
+// ErrInvitationNotExists is returned when accepting an invitation that does not exist or was already accepted
+var ErrInvitationNotExists = errors.New("the invitation does not exist")
+
+// ErrParticipantNotExists is returned when revoking a player that does not participate in the game
+var ErrParticipantNotExists = errors.New("the player does not participate in the game")
+
+// ErrRevokeOwner is returned when attempting to revoke the owner of a game
+var ErrRevokeOwner = errors.New("the owner of the game cannot be revoked")
+
+// Participant is a player invited to a game with its role in the game. The player can access the game once the
+// invitation is accepted.
+type Participant struct {
+	Player     Creator   `boil:",bind" json:"player"`
+	Role       string    `boil:"role" json:"role"`
+	AcceptedAt null.Time `boil:"accepted_at" json:"acceptedAt,omitempty"`
+}
+
+// Invitation is a player invited by the owner of a game to play it or to watch it
+type Invitation struct {
+	PlayerID int64  `json:"playerId" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=player spectator"`
+}
+
+// FindParticipants retrieves the players invited to a game in the order they were invited
+func (api api) FindParticipants(ctx context.Context, user security.JWTUser, id int64) ([]Participant, error) {
+	participants := []Participant{}
+	exists, err := models.Games(qm.Where("id = ? AND "+accessibleGame, id, user.ID)).Exists(ctx, api.db)
+	if err != nil {
+		api.logger.Printf("error retrieving game: %v", err)
+		return participants, err
+	}
+	if !exists {
+		return participants, response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrGameNotExists.Error(),
+		}
+	}
+	err = queries.Raw(`
+		SELECT p.id as "players.id", p.name as "players.name",
+		gp.role as "role", gp.accepted_at as "accepted_at"
+		FROM game_participants gp INNER JOIN players p ON gp.player_id = p.id
+		WHERE gp.game_id = $1
+		ORDER BY gp.id`, id,
+	).Bind(ctx, api.db, &participants)
+	if err != nil {
+		api.logger.Printf("error retrieving game participants: %v", err)
+	}
+	return participants, err
+}
+
+// InviteParticipant invites a player to play or to watch a game. Only the owner of the game can invite players.
+func (api api) InviteParticipant(ctx context.Context, user security.JWTUser, id int64, invitation Invitation) (Participant, error) {
+	participant := Participant{
+		Role: invitation.Role,
+	}
+	err := api.checkOwner(ctx, id, user)
+	if err != nil {
+		return participant, err
+	}
+	player, err := models.FindPlayer(ctx, api.db, invitation.PlayerID, models.PlayerColumns.ID, models.PlayerColumns.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return participant, response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrPlayerNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving player: %v", err)
+		return participant, err
+	}
+	participant.Player = Creator{
+		ID:   player.ID,
+		Name: player.Name,
+	}
+	gameParticipant := &models.GameParticipant{
+		GameID:   id,
+		PlayerID: player.ID,
+		Role:     invitation.Role,
+	}
+	err = gameParticipant.Insert(ctx, api.db, boil.Infer())
+	if err != nil {
+		if pgerr, ok := extErrors.Cause(err).(*pq.Error); ok && pgerr.Constraint == uniqueGameParticipantConstaintName {
+			return participant, response.HTTPError{
+				Code:    http.StatusConflict,
+				Message: ErrAlreadyParticipant.Error(),
+			}
+		}
+		api.logger.Printf("error inserting game participant: %v", err)
+	}
+	return participant, err
+}
+
+// AcceptInvitation accepts the invitation of a player to a game, which gives the player access to the game
+func (api api) AcceptInvitation(ctx context.Context, user security.JWTUser, id int64) error {
+	accepted, err := models.GameParticipants(qm.Where("game_id = ? AND player_id = ? AND accepted_at IS NULL", id, user.ID)).
+		UpdateAll(ctx, api.db, models.M{"accepted_at": time.Now().UTC()})
+	if err != nil {
+		api.logger.Printf("error accepting invitation: %v", err)
+		return err
+	}
+	if accepted == 0 {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrInvitationNotExists.Error(),
+		}
+	}
+	return nil
+}
+
+// RevokeParticipant takes away the access of a player to a game. The owner of a game can revoke any other player
+// and a player can decline an invitation or leave a game by revoking their own participation.
+func (api api) RevokeParticipant(ctx context.Context, user security.JWTUser, id int64, playerID int64) error {
+	if playerID != user.ID {
+		err := api.checkOwner(ctx, id, user)
+		if err != nil {
+			return err
+		}
+	}
+	participant, err := models.GameParticipants(qm.Where("game_id = ? AND player_id = ?", id, playerID)).One(ctx, api.db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrParticipantNotExists.Error(),
+			}
+		}
+		api.logger.Printf("error retrieving game participant: %v", err)
+		return err
+	}
+	if participant.Role == models.ParticipantRoleOwner {
+		return response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrRevokeOwner.Error(),
+		}
+	}
+	_, err = participant.Delete(ctx, api.db)
+	if err != nil {
+		api.logger.Printf("error deleting game participant: %v", err)
+	}
+	return err
+}
+
+// checkOwner returns an error when the game does not exist or the player is not its owner
+func (api api) checkOwner(ctx context.Context, id int64, user security.JWTUser) error {
+	participant, err := api.retrieveParticipant(ctx, id, user)
+	if err != nil {
+		return err
+	}
+	if participant == nil {
+		exists, err := models.Games(qm.Where("id = ? AND "+accessibleGame, id, user.ID)).Exists(ctx, api.db)
+		if err != nil {
+			api.logger.Printf("error retrieving game: %v", err)
+			return err
+		}
+		if !exists {
+			return response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		}
+	}
+	if participant == nil || participant.Role != models.ParticipantRoleOwner {
+		return response.HTTPError{
+			Code:    http.StatusForbidden,
+			Message: ErrNotGameCreator.Error(),
+		}
+	}
+	return nil
+}
+
+// retrieveParticipant retrieves the accepted participation of a player in a game, or nil when the player does not
+// participate in the game
+func (api api) retrieveParticipant(ctx context.Context, gameID int64, user security.JWTUser) (*models.GameParticipant, error) {
+	participant, err := models.GameParticipants(
+		qm.Where("game_id = ? AND player_id = ? AND accepted_at IS NOT NULL", gameID, user.ID),
+	).One(ctx, api.db)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		api.logger.Printf("error retrieving game participant: %v", err)
+	}
+	return participant, err
+}
+
+// isSpectator returns true when the player can only watch the game. The participants play the game unless they
+// were invited as spectators, and the rest of the players only watch spectate only games.
+func isSpectator(game *models.Game, participant *models.GameParticipant) bool {
+	if participant != nil {
+		return participant.Role == models.ParticipantRoleSpectator
+	}
+	return game.SpectateOnly
+}
+
+// insertOwner makes the creator of a game its owner
+func insertOwner(ctx context.Context, executor boil.ContextExecutor, game *models.Game) error {
+	owner := &models.GameParticipant{
+		GameID:     game.ID,
+		PlayerID:   game.CreatorID,
+		Role:       models.ParticipantRoleOwner,
+		AcceptedAt: null.TimeFrom(time.Now().UTC()),
+	}
+	return owner.Insert(ctx, executor, boil.Infer())
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/http/security"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// minePenalty is the amount of points that a player of a competitive game loses when hitting a mine
+const minePenalty = 10
+
+// ErrCompetitiveNotShared is returned when attempting to create a competitive game that other players cannot play
+var ErrCompetitiveNotShared = errors.New("competitive games must be public, open play and not casual")
+
+// ErrPlayerEliminated is returned when a player that hit a mine in a competitive game attempts to keep playing
+var ErrPlayerEliminated = errors.New("the player hit a mine and was eliminated")
+
+// PlayerScore is the score of a player in a competitive game, ranked by the score of the players still standing and
+// then by who scored their last points first. An eliminated player hit a mine and can no longer play.
+type PlayerScore struct {
+	Player       Creator  `boil:",bind" json:"player"`
+	Score        int      `boil:"score" json:"score"`
+	Eliminated   bool     `boil:"eliminated" json:"eliminated"`
+	LastScoredID null.Int `boil:"last_scored_id" json:"-"`
+}
+
+// checkNotEliminated returns an error when the player was eliminated from a competitive game
+func (api api) checkNotEliminated(ctx context.Context, executor boil.ContextExecutor, game *models.Game, user security.JWTUser) error {
+	if !game.Competitive {
+		return nil
+	}
+	eliminated, err := models.GameOperations(
+		qm.Where("game_id = ? AND player_id = ? AND score < 0", game.ID, user.ID),
+	).Exists(ctx, executor)
+	if err != nil {
+		api.logger.Printf("error checking if the player was eliminated: %v", err)
+		return err
+	}
+	if eliminated {
+		return response.HTTPError{
+			Code:    http.StatusForbidden,
+			Message: ErrPlayerEliminated.Error(),
+		}
+	}
+	return nil
+}
+
+// scoreOperation stores the score of a committed operation and sets the scores of the players in the status
+func (api api) scoreOperation(ctx context.Context, tx *sql.Tx, confirmation *OperationConfirmation, score int) error {
+	gameID := confirmation.Operation.GameID
+	if score != 0 {
+		_, err := models.GameOperations(qm.Where("game_id = ? AND operation_id = ?", gameID, confirmation.Operation.ID)).
+			UpdateAll(ctx, tx, models.M{"score": score})
+		if err != nil {
+			return err
+		}
+	}
+	var err error
+	confirmation.Status.Scores, err = retrieveScores(ctx, tx, gameID)
+	return err
+}
+
+// operationScore returns the points that an operation scores in a competitive game: every point it revealed, or
+// a penalty when it hit a mine
+func operationScore(oper Operation, mineProximity algebra.MineProximity) int {
+	if mineProximity == algebra.RevealedMine {
+		return -minePenalty
+	}
+	if oper.Op == algebra.OpReveal {
+		return len(oper.Result)
+	} else if oper.Op == algebra.OpChord {
+		// the first result of a chord is its own point, which was already revealed
+		return len(oper.Result) - 1
+	}
+	return 0
+}
+
+// retrieveScores retrieves the ranked scores of the players of a competitive game. The players that only asked
+// for hints are not part of the game.
+func retrieveScores(ctx context.Context, executor boil.ContextExecutor, gameID int64) ([]PlayerScore, error) {
+	scores := []PlayerScore{}
+	err := queries.Raw(`
+		SELECT p.id as "players.id", p.name as "players.name",
+		SUM(o.score) as "score", bool_or(o.score < 0) as "eliminated",
+		MAX(CASE WHEN o.score > 0 THEN o.operation_id END) as "last_scored_id"
+		FROM game_operations o INNER JOIN players p ON o.player_id = p.id
+		WHERE o.game_id = $1 AND o.operation <> 'hint'
+		GROUP BY p.id, p.name`, gameID,
+	).Bind(ctx, executor, &scores)
+	rankScores(scores)
+	return scores, err
+}
+
+// rankScores sorts the scores of a competitive game, the players still standing go first, ordered by their score
+// and then by who scored their last points first
+func rankScores(scores []PlayerScore) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		a, b := scores[i], scores[j]
+		if a.Eliminated != b.Eliminated {
+			return !a.Eliminated
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.LastScoredID.Valid != b.LastScoredID.Valid {
+			return a.LastScoredID.Valid
+		}
+		return a.LastScoredID.Int < b.LastScoredID.Int
+	})
+}
+
+// winner returns the best ranked player still standing in a competitive game or nil when every player was
+// eliminated
+func winner(scores []PlayerScore) *Creator {
+	if len(scores) == 0 || scores[0].Eliminated {
+		return nil
+	}
+	player := scores[0].Player
+	return &player
+}
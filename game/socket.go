@@ -0,0 +1,136 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/http/security"
+	"github.com/labstack/echo"
+	"golang.org/x/net/websocket"
+)
+
+// socketOperationTimeout is the time that an operation sent through a game socket has to be applied
+const socketOperationTimeout = 10 * time.Second
+
+type evResponse struct {
+	Event Event `json:"event"`
+}
+
+// Socket is the websocket handler that pushes every change of a game to a player and applies the operations
+// that the player sends through it. Every message sent to the player has the same format as an http response,
+// with either an event or the confirmation of an operation sent by the player. The socket is not closed by the server
+// timeouts.
+func (h Handler) Socket(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	// only the players that can see a game can follow it
+	_, err = api.RetrieveGame(ctx, user, gameID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	eventChan := events.follow(gameID)
+	defer events.unfollow(gameID, eventChan)
+	websocket.Handler(func(ws *websocket.Conn) {
+		defer ws.Close()
+		// the read and write timeouts of the server apply to the whole request, the socket stays open until it is closed
+		err := ws.SetDeadline(time.Time{})
+		if err != nil {
+			h.logger.Printf("error clearing game %d socket deadlines: %v\n", gameID, err)
+			return
+		}
+		replies := make(chan response.ServiceResponse)
+		done := make(chan struct{})
+		defer close(done)
+		go h.receiveOperations(ctx, c, api, user, gameID, ws, replies, done)
+		for {
+			var message response.ServiceResponse
+			select {
+			case e, ok := <-eventChan:
+				if !ok {
+					// the player fell behind, closing the socket makes the client retrieve the game again
+					return
+				}
+				message = response.NewSuccessMessage(evResponse{e})
+			case reply, ok := <-replies:
+				if !ok {
+					return
+				}
+				message = reply
+			}
+			err := websocket.JSON.Send(ws, message)
+			if err != nil {
+				h.logger.Printf("error sending message to game %d socket: %v\n", gameID, err)
+				return
+			}
+		}
+	}).ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// receiveOperations applies every operation received through a game socket until the socket is closed
+func (h Handler) receiveOperations(ctx context.Context, c echo.Context, api API, user security.JWTUser, gameID int64, ws *websocket.Conn, replies chan<- response.ServiceResponse, done <-chan struct{}) {
+	defer close(replies)
+	for {
+		var data []byte
+		err := websocket.Message.Receive(ws, &data)
+		if err != nil {
+			if err != io.EOF {
+				h.logger.Printf("error receiving message from game %d socket: %v\n", gameID, err)
+			}
+			return
+		}
+		var reply response.ServiceResponse
+		oper := Operation{}
+		err = json.Unmarshal(data, &oper)
+		if err != nil {
+			h.logger.Printf("could not bind socket message %v\n", err)
+			reply = response.NewErrorMessage(response.HTTPError{
+				Code:    http.StatusBadRequest,
+				Message: "id, op, row, col are required",
+			})
+		} else {
+			// the socket belongs to a single game
+			oper.GameID = gameID
+			if err = c.Validate(oper); err != nil {
+				h.logger.Printf("validation error %v\n", err)
+				reply = response.NewErrorMessage(response.HTTPError{
+					Code:    http.StatusBadRequest,
+					Message: err.Error(),
+				})
+			} else {
+				operCtx, cancel := context.WithTimeout(ctx, socketOperationTimeout)
+				confirmation, err := api.ApplyOperation(operCtx, user, oper)
+				cancel()
+				if err != nil {
+					reply = response.NewErrorMessage(err)
+				} else {
+					reply = response.NewSuccessMessage(cResponse{confirmation})
+				}
+			}
+		}
+		select {
+		case replies <- reply:
+		case <-done:
+			return
+		}
+	}
+}
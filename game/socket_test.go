@@ -0,0 +1,104 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/http/security"
+	testHelpers "github.com/javiercbk/minesweeper/testing"
+	"golang.org/x/net/websocket"
+)
+
+const streamSecret = "streamSecret"
+
+// serverTimeout is the read and write timeout of the test server, every stream must outlive it
+const serverTimeout = 200 * time.Millisecond
+
+// streamAPI is a game API that lets any player follow a game and applies every operation
+type streamAPI struct {
+	API
+}
+
+func (streamAPI) RetrieveGame(ctx context.Context, user security.JWTUser, id int64) (StatefulGame, error) {
+	return StatefulGame{ID: id}, nil
+}
+
+func (streamAPI) FindEvents(ctx context.Context, user security.JWTUser, id int64, afterID int) ([]Event, error) {
+	return []Event{}, nil
+}
+
+func (streamAPI) ApplyOperation(ctx context.Context, user security.JWTUser, oper Operation) (OperationConfirmation, error) {
+	oper.Applied = true
+	return OperationConfirmation{Operation: oper}, nil
+}
+
+// newStreamServer starts a server of the game routes with short read and write timeouts and returns it with the
+// token of a player
+func newStreamServer(t *testing.T) (*httptest.Server, string) {
+	apiFactory = func(logger *log.Logger, db *sql.DB) API {
+		return streamAPI{}
+	}
+	e := testHelpers.MockEcho()
+	handler := NewHandler(testHelpers.NullLogger(), nil)
+	handler.Routes(e.Group("/api/games", security.JWTMiddlewareFactory(streamSecret)))
+	srv := httptest.NewUnstartedServer(e)
+	srv.Config.ReadTimeout = serverTimeout
+	srv.Config.WriteTimeout = serverTimeout
+	srv.Start()
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims = security.JWTEncode(security.JWTUser{ID: 1, Name: "streamer"}, time.Minute)
+	signed, err := token.SignedString([]byte(streamSecret))
+	if err != nil {
+		srv.Close()
+		t.Fatalf("error signing token %v\n", err)
+	}
+	return srv, signed
+}
+
+func TestSocketOutlivesServerTimeout(t *testing.T) {
+	srv, token := newStreamServer(t)
+	defer srv.Close()
+	defer func() {
+		apiFactory = NewAPI
+	}()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/games/1/socket?token=" + token
+	ws, err := websocket.Dial(url, "", srv.URL)
+	if err != nil {
+		t.Fatalf("error connecting to the game socket %v\n", err)
+	}
+	defer ws.Close()
+	time.Sleep(3 * serverTimeout)
+	err = websocket.JSON.Send(ws, Operation{ID: 1, GameID: 1, Op: algebra.OpMark})
+	if err != nil {
+		t.Fatalf("error sending operation %v\n", err)
+	}
+	reply := struct {
+		Data cResponse `json:"data"`
+	}{}
+	err = websocket.JSON.Receive(ws, &reply)
+	if err != nil {
+		t.Fatalf("error receiving the operation confirmation %v\n", err)
+	}
+	if !reply.Data.Confirmation.Operation.Applied {
+		t.Fatalf("expected the operation to be applied but was %v\n", reply.Data.Confirmation)
+	}
+	time.Sleep(3 * serverTimeout)
+	events.publish(1, hintEvent(1, Hint{OperationID: 2}))
+	message := struct {
+		Data evResponse `json:"data"`
+	}{}
+	err = websocket.JSON.Receive(ws, &message)
+	if err != nil {
+		t.Fatalf("error receiving the event %v\n", err)
+	}
+	if message.Data.Event.Operation == nil || message.Data.Event.Operation.ID != 2 {
+		t.Fatalf("expected the hint event but got %v\n", message.Data.Event)
+	}
+}
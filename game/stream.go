@@ -0,0 +1,173 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/http/security"
+	"github.com/labstack/echo"
+)
+
+const (
+	// streamKeepAlive is how often a comment is sent through an idle event stream so proxies do not close it
+	streamKeepAlive = 15 * time.Second
+	// finalEventSuffix is added to the id of the final status event of a game, the id of the last operation
+	finalEventSuffix  = "-final"
+	headerLastEventID = "Last-Event-ID"
+	mimeEventStream   = "text/event-stream"
+)
+
+// Events is the http handler that streams the changes of a game as server-sent events. Every committed operation
+// is an "operation" event with the operation id as the event id, and the final status of the game, with the whole
+// board, is a "status" event that closes the stream. A client that reconnects with a Last-Event-ID receives the
+// operations it missed first, and a client that already received the final status receives a 204. The stream takes
+// over the connection so the server timeouts do not close it.
+func (h Handler) Events(c echo.Context) error {
+	user, err := security.JWTDecode(c)
+	if err == security.ErrUserNotFound {
+		h.logger.Printf("error finding jwt token in context: %v\n", err)
+		return response.NewErrorResponse(c, http.StatusForbidden, "authentication token was not found")
+	}
+	gameIDStr := c.Param("gameID")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("game %s does not exist", gameIDStr),
+		}
+	}
+	lastID, finalSent := parseEventID(c.Request().Header.Get(headerLastEventID))
+	ctx := c.Request().Context()
+	api := apiFactory(h.logger, h.db)
+	// follow the game before looking for the missed events so no event is lost in between
+	eventChan := events.follow(gameID)
+	defer events.unfollow(gameID, eventChan)
+	missed, err := api.FindEvents(ctx, user, gameID, lastID)
+	if err != nil {
+		return response.NewResponseFromError(c, err)
+	}
+	if finalSent && len(missed) == 1 && missed[0].Status != nil {
+		// the client has seen the whole game, a 204 stops it from reconnecting
+		return c.NoContent(http.StatusNoContent)
+	}
+	conn, w, err := hijackStream(c)
+	if err != nil {
+		h.logger.Printf("error opening game %d event stream: %v\n", gameID, err)
+		return response.NewResponseFromError(c, err)
+	}
+	defer conn.Close()
+	closed := make(chan struct{})
+	go func() {
+		// the client sends nothing else, reading only ends when it goes away
+		_, _ = io.Copy(ioutil.Discard, w)
+		close(closed)
+	}()
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		for _, e := range missed {
+			if e.Operation != nil && e.Operation.ID > lastID {
+				lastID = e.Operation.ID
+				err = writeEvent(w, strconv.Itoa(lastID), "operation", e.Operation)
+				if err != nil {
+					h.logger.Printf("error writing game %d event: %v\n", gameID, err)
+					return nil
+				}
+			}
+			if e.Status != nil {
+				err = writeEvent(w, strconv.Itoa(lastID)+finalEventSuffix, "status", e.Status)
+				if err != nil {
+					h.logger.Printf("error writing game %d event: %v\n", gameID, err)
+				}
+				w.Flush()
+				return nil
+			}
+		}
+		err = w.Flush()
+		if err != nil {
+			return nil
+		}
+		select {
+		case e, ok := <-eventChan:
+			if !ok {
+				// the client fell behind, it resumes from the last event when it reconnects
+				return nil
+			}
+			missed = []Event{e}
+		case <-keepAlive.C:
+			missed = nil
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+			if err != nil {
+				return nil
+			}
+		case <-closed:
+			return nil
+		}
+	}
+}
+
+// hijackStream takes over the connection of an event stream and writes the response headers. The read and write
+// timeouts of the server apply to the whole request, so the deadlines of the connection are cleared and the
+// response ends when the connection is closed.
+func hijackStream(c echo.Context) (net.Conn, *bufio.ReadWriter, error) {
+	res := c.Response()
+	conn, w, err := res.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	res.Committed = true
+	err = conn.SetDeadline(time.Time{})
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	res.Header().Set(echo.HeaderContentType, mimeEventStream)
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.Header().Set("Connection", "close")
+	_, err = fmt.Fprintf(w, "HTTP/1.1 %d %s\r\n", http.StatusOK, http.StatusText(http.StatusOK))
+	if err == nil {
+		err = res.Header().Write(w)
+	}
+	if err == nil {
+		_, err = io.WriteString(w, "\r\n")
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, w, nil
+}
+
+// writeEvent writes a server-sent event with its data as JSON
+func writeEvent(w io.Writer, id, name string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", id, name, b)
+	return err
+}
+
+// parseEventID returns the operation id of a Last-Event-ID and whether the event was the final status of the
+// game. An unknown event id streams the game from its first operation.
+func parseEventID(eventID string) (int, bool) {
+	final := strings.HasSuffix(eventID, finalEventSuffix)
+	id, err := strconv.Atoi(strings.TrimSuffix(eventID, finalEventSuffix))
+	if err != nil || id < 0 {
+		return 0, false
+	}
+	return id, final
+}
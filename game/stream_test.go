@@ -0,0 +1,76 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEventID(t *testing.T) {
+	testTable := []struct {
+		eventID string
+		id      int
+		final   bool
+	}{
+		{eventID: "", id: 0, final: false},
+		{eventID: "12", id: 12, final: false},
+		{eventID: "12-final", id: 12, final: true},
+		{eventID: "0-final", id: 0, final: true},
+		{eventID: "-3", id: 0, final: false},
+		{eventID: "twelve-final", id: 0, final: false},
+	}
+	for i, test := range testTable {
+		id, final := parseEventID(test.eventID)
+		if id != test.id || final != test.final {
+			t.Fatalf("test %d failed: expected id %d and final %t but got %d and %t\n", i, test.id, test.final, id, final)
+		}
+	}
+}
+
+func TestWriteEvent(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeEvent(&buf, "7", "operation", Operation{ID: 7, GameID: 2, Applied: true})
+	if err != nil {
+		t.Fatalf("error writing event: %v\n", err)
+	}
+	expected := "id: 7\nevent: operation\ndata: {\"id\":7,\"gameId\":2,\"op\":0,\"layer\":0,\"row\":0,\"col\":0,\"applied\":true}\n\n"
+	if buf.String() != expected {
+		t.Fatalf("expected event %q but got %q\n", expected, buf.String())
+	}
+}
+
+func TestEventStreamOutlivesServerTimeout(t *testing.T) {
+	srv, token := newStreamServer(t)
+	defer srv.Close()
+	defer func() {
+		apiFactory = NewAPI
+	}()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/games/1/events?token="+token, nil)
+	if err != nil {
+		t.Fatalf("error creating request %v\n", err)
+	}
+	req.Header.Set("Accept", mimeEventStream)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("error opening the event stream %v\n", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != mimeEventStream {
+		t.Fatalf("expected an event stream but got %d %s\n", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	time.Sleep(3 * serverTimeout)
+	events.publish(1, hintEvent(1, Hint{OperationID: 3}))
+	lines := bufio.NewScanner(res.Body)
+	for lines.Scan() {
+		if strings.HasPrefix(lines.Text(), "id: ") {
+			if lines.Text() != "id: 3" {
+				t.Fatalf("expected the hint event but got %q\n", lines.Text())
+			}
+			return
+		}
+	}
+	t.Fatalf("expected the stream to stay open but it was closed: %v\n", lines.Err())
+}
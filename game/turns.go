@@ -0,0 +1,209 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/javiercbk/minesweeper/http/response"
+	"github.com/javiercbk/minesweeper/http/security"
+	"github.com/javiercbk/minesweeper/models"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// ErrTurnBasedNotShared is returned when attempting to create a turn based game that other players cannot play
+var ErrTurnBasedNotShared = errors.New("turn based games must be public, open play, not casual and not competitive")
+
+// ErrGameNotTurnBased is returned when attempting to join a game that is not turn based
+var ErrGameNotTurnBased = errors.New("only turn based games can be joined")
+
+// ErrAlreadyJoined is returned when a player attempts to join a game twice
+var ErrAlreadyJoined = errors.New("the player has already joined the game")
+
+// ErrNotJoined is returned when a player that has not joined a turn based game attempts to play it
+var ErrNotJoined = errors.New("the player must join the game to play it")
+
+// ErrNotYourTurn is returned when a player attempts to play a turn based game out of turn
+var ErrNotYourTurn = errors.New("it is not the turn of the player")
+
+// JoinGame adds a player at the end of the turn order of a turn based game
+func (api api) JoinGame(ctx context.Context, user security.JWTUser, id int64) error {
+	tx, err := api.db.BeginTx(ctx, nil)
+	if err != nil {
+		api.logger.Printf("error beggining transaction for joining game: %v\n", err)
+		return err
+	}
+	// locking the game serializes the players joining it and passing its turns
+	game, err := models.Games(
+		qm.Where("id = ? AND "+accessibleGame, id, user.ID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err == nil && game.FinishedAt.Valid {
+		err = response.HTTPError{
+			Code:    http.StatusNotFound,
+			Message: ErrGameFinished.Error(),
+		}
+	} else if err == nil && !game.TurnBased {
+		err = response.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: ErrGameNotTurnBased.Error(),
+		}
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = response.HTTPError{
+				Code:    http.StatusNotFound,
+				Message: ErrGameNotExists.Error(),
+			}
+		} else if _, ok := err.(response.HTTPError); !ok {
+			api.logger.Printf("error retrieving game: %v", err)
+		}
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game join with error: %v\n", rollbackError)
+		}
+		return err
+	}
+	turnOrder, err := retrieveTurnOrder(ctx, tx, id)
+	if err != nil {
+		api.logger.Printf("error retrieving game turn order: %v", err)
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game join with error: %v\n", rollbackError)
+		}
+		return err
+	}
+	if turnIndex(turnOrder, user) >= 0 {
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game join with error: %v\n", rollbackError)
+		}
+		return response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrAlreadyJoined.Error(),
+		}
+	}
+	// the turns that already ran out are settled before the new player changes the length of the turn order
+	position, startedAt := currentTurn(game.TurnPosition, len(turnOrder), game.TurnStartedAt.Time, turnLimit(game.TurnSeconds), time.Now().UTC())
+	_, err = models.Games(qm.Where("id = ?", id)).
+		UpdateAll(ctx, tx, models.M{"turn_position": position, "turn_started_at": startedAt})
+	if err != nil {
+		api.logger.Printf("error settling the game turn: %v", err)
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game join with error: %v\n", rollbackError)
+		}
+		return err
+	}
+	playerTurn := &models.GameTurn{
+		GameID:   id,
+		PlayerID: user.ID,
+		Position: int16(len(turnOrder)),
+	}
+	err = playerTurn.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		api.logger.Printf("error inserting the player turn: %v", err)
+		// just log rollback error
+		rollbackError := tx.Rollback()
+		if rollbackError != nil {
+			api.logger.Printf("error rolling back game join with error: %v\n", rollbackError)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// checkTurn returns an error when the player cannot play a turn based game right now
+func (api api) checkTurn(ctx context.Context, game *models.Game, user security.JWTUser) error {
+	if !game.TurnBased {
+		return nil
+	}
+	turnOrder, err := retrieveTurnOrder(ctx, api.db, game.ID)
+	if err != nil {
+		api.logger.Printf("error retrieving game turn order: %v", err)
+		return err
+	}
+	if turnIndex(turnOrder, user) < 0 {
+		return response.HTTPError{
+			Code:    http.StatusForbidden,
+			Message: ErrNotJoined.Error(),
+		}
+	}
+	position, _ := currentTurn(game.TurnPosition, len(turnOrder), game.TurnStartedAt.Time, turnLimit(game.TurnSeconds), time.Now().UTC())
+	if turnOrder[position].ID != user.ID {
+		return response.HTTPError{
+			Code:    http.StatusConflict,
+			Message: ErrNotYourTurn.Error(),
+		}
+	}
+	return nil
+}
+
+// passTurn gives the turn of a turn based game, locked by the transaction, to the next player. ErrNotYourTurn is
+// returned when the turn of the player ran out or was already played.
+func (api api) passTurn(ctx context.Context, tx *sql.Tx, game *models.Game, user security.JWTUser) error {
+	turnOrder, err := retrieveTurnOrder(ctx, tx, game.ID)
+	if err != nil {
+		return err
+	}
+	if len(turnOrder) == 0 {
+		return ErrNotYourTurn
+	}
+	now := time.Now().UTC()
+	position, _ := currentTurn(game.TurnPosition, len(turnOrder), game.TurnStartedAt.Time, turnLimit(game.TurnSeconds), now)
+	if turnOrder[position].ID != user.ID {
+		return ErrNotYourTurn
+	}
+	_, err = models.Games(qm.Where("id = ?", game.ID)).
+		UpdateAll(ctx, tx, models.M{"turn_position": (position + 1) % len(turnOrder), "turn_started_at": now})
+	return err
+}
+
+// retrieveTurnOrder retrieves the players of a turn based game in the order they play
+func retrieveTurnOrder(ctx context.Context, executor boil.ContextExecutor, gameID int64) ([]Creator, error) {
+	turnOrder := []Creator{}
+	err := queries.Raw(`
+		SELECT p.id as "players.id", p.name as "players.name"
+		FROM game_turns t INNER JOIN players p ON t.player_id = p.id
+		WHERE t.game_id = $1
+		ORDER BY t.position`, gameID,
+	).Bind(ctx, executor, &turnOrder)
+	return turnOrder, err
+}
+
+// turnIndex returns the index of the player in the turn order or -1 when the player has not joined the game
+func turnIndex(turnOrder []Creator, user security.JWTUser) int {
+	for i, player := range turnOrder {
+		if player.ID == user.ID {
+			return i
+		}
+	}
+	return -1
+}
+
+// turnLimit is the time that a player has to play a turn, zero when the turns have no time limit
+func turnLimit(turnSeconds int) time.Duration {
+	return time.Duration(turnSeconds) * time.Second
+}
+
+// currentTurn returns the position in the turn order of the player that has to play and when the turn started.
+// Every turn that ran out of time is passed to the next player without waiting for anybody to play it.
+func currentTurn(position, players int, startedAt time.Time, limit time.Duration, now time.Time) (int, time.Time) {
+	if players == 0 {
+		return 0, startedAt
+	}
+	if limit > 0 && now.After(startedAt) {
+		passed := now.Sub(startedAt) / limit
+		position += int(passed % time.Duration(players))
+		startedAt = startedAt.Add(passed * limit)
+	}
+	return position % players, startedAt
+}
@@ -0,0 +1,427 @@
+// Package gamefile reads and writes finished games in a portable, versioned file format. A file can be written
+// as JSON or as a compact text form.
+//
+// The text form starts with a line with the format name and version, followed by one "key value" line for every
+// field of the game, a "board" line followed by one line for every row of the board and an "operations" line
+// followed by one line for every operation of the game:
+//
+//	minesweeper-game 2
+//	layers 1
+//	rows 2
+//	cols 2
+//	mines 1
+//	topology square
+//	wrap false
+//	seed 42
+//	generator 1
+//	noguess false
+//	won true
+//	resigned false
+//	started 2019-03-01T10:00:00Z
+//	finished 2019-03-01T10:01:00Z
+//	elapsed 60000
+//	board
+//	1 1
+//	1 -56
+//	operations
+//	1 mark 0 1 1 -56 2019-03-01T10:00:01Z player name
+//
+// Every row of the board has the mine proximity values of its points separated by spaces. An operation line has
+// the operation id, the operation, the layer, row and column of the point, the resulting mine proximity, the time
+// it was made or '-' when it is unknown and the name of the player that made it. The started time is also '-'
+// when the game finished before its first operation.
+//
+// Version 1 files, where a point could only have 8 mines around, are still read and converted to the current
+// version. Their board rows have a single character per point: '0' to '9' are revealed points, where '9' is a
+// revealed mine, 'a' to 'j' are unrevealed points with a mine proximity of -1 to -10, 'A' to 'J' are points
+// marked as suspect of having a mine and 'K' to 'T' are points marked as certainly having a mine.
+package gamefile
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/volatiletech/null"
+)
+
+const (
+	// Format is the name that identifies a game file
+	Format = "minesweeper-game"
+	// Version is the version of the game file format
+	Version = 2
+	// version1 is the first version of the format, where a point could only have 8 mines around
+	version1 = 1
+)
+
+var (
+	// ErrInvalidFile is returned when a file is not a game file or it describes an invalid game
+	ErrInvalidFile = errors.New("invalid game file")
+	// ErrUnsupportedVersion is returned when a file was written with an unknown version of the format
+	ErrUnsupportedVersion = errors.New("unsupported game file version")
+)
+
+// File is a finished game. The rows of every layer of the board are stacked one after the other and every point
+// has its mine proximity value.
+type File struct {
+	Format           string      `json:"format"`
+	Version          int         `json:"version"`
+	Layers           int         `json:"layers"`
+	Rows             int         `json:"rows"`
+	Cols             int         `json:"cols"`
+	Mines            int         `json:"mines"`
+	Topology         string      `json:"topology"`
+	Wrap             bool        `json:"wrap"`
+	Seed             int64       `json:"seed"`
+	GeneratorVersion int         `json:"generatorVersion"`
+	NoGuess          bool        `json:"noGuess"`
+	Won              bool        `json:"won"`
+	Resigned         bool        `json:"resigned"`
+	StartedAt        null.Time   `json:"startedAt"`
+	FinishedAt       null.Time   `json:"finishedAt"`
+	Elapsed          int64       `json:"elapsed"`
+	Board            [][]int     `json:"board"`
+	Operations       []Operation `json:"operations"`
+}
+
+// Operation is an operation of a game file
+type Operation struct {
+	ID            int       `json:"id"`
+	Op            string    `json:"op"`
+	Layer         int       `json:"layer"`
+	Row           int       `json:"row"`
+	Col           int       `json:"col"`
+	MineProximity int       `json:"mineProximity"`
+	CreatedAt     null.Time `json:"createdAt"`
+	Player        string    `json:"player"`
+}
+
+// New creates a file of the current version
+func New() File {
+	return File{
+		Format:  Format,
+		Version: Version,
+	}
+}
+
+// Validate checks that the file is a game file of a supported version that describes a finished game
+func (f File) Validate() error {
+	if f.Format != Format {
+		return ErrInvalidFile
+	}
+	if f.Version != Version {
+		return ErrUnsupportedVersion
+	}
+	if f.Layers <= 0 || f.Rows <= 0 || f.Cols <= 0 || f.Mines <= 0 || f.Elapsed < 0 || !f.FinishedAt.Valid {
+		return ErrInvalidFile
+	}
+	if len(f.Board) != f.Layers*f.Rows {
+		return ErrInvalidFile
+	}
+	mines := 0
+	for _, row := range f.Board {
+		if len(row) != f.Cols {
+			return ErrInvalidFile
+		}
+		for _, p := range row {
+			if p < algebra.MarkedMine || p > algebra.RevealedMine {
+				return ErrInvalidFile
+			}
+			if p == algebra.RevealedMine || (p < 0 && (-p)%algebra.MarkOffset == 0) {
+				mines++
+			}
+		}
+	}
+	if mines != f.Mines {
+		return ErrInvalidFile
+	}
+	lastID := 0
+	for _, o := range f.Operations {
+		// operations are ordered by their unique id
+		if o.ID <= lastID || o.Layer < 0 || o.Layer >= f.Layers || o.Row < 0 || o.Row >= f.Rows || o.Col < 0 || o.Col >= f.Cols {
+			return ErrInvalidFile
+		}
+		if o.Op == "" || strings.ContainsAny(o.Op, " \t\n") || strings.Contains(o.Player, "\n") {
+			return ErrInvalidFile
+		}
+		lastID = o.ID
+	}
+	return nil
+}
+
+// ReadJSON reads and validates a file in its JSON form
+func ReadJSON(r io.Reader) (File, error) {
+	f := File{}
+	err := json.NewDecoder(r).Decode(&f)
+	if err != nil {
+		return f, ErrInvalidFile
+	}
+	if f.Format == Format && f.Version == version1 {
+		f.upgrade()
+	}
+	return f, f.Validate()
+}
+
+// WriteText writes a file in its compact text form
+func WriteText(w io.Writer, f File) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s %d\n", f.Format, f.Version)
+	fmt.Fprintf(bw, "layers %d\nrows %d\ncols %d\nmines %d\n", f.Layers, f.Rows, f.Cols, f.Mines)
+	fmt.Fprintf(bw, "topology %s\nwrap %t\nseed %d\ngenerator %d\nnoguess %t\n", f.Topology, f.Wrap, f.Seed, f.GeneratorVersion, f.NoGuess)
+	fmt.Fprintf(bw, "won %t\nresigned %t\n", f.Won, f.Resigned)
+	fmt.Fprintf(bw, "started %s\nfinished %s\nelapsed %d\n", formatTime(f.StartedAt), formatTime(f.FinishedAt), f.Elapsed)
+	bw.WriteString("board\n")
+	for _, row := range f.Board {
+		for i, p := range row {
+			if i > 0 {
+				bw.WriteByte(' ')
+			}
+			bw.WriteString(strconv.Itoa(p))
+		}
+		bw.WriteByte('\n')
+	}
+	bw.WriteString("operations\n")
+	for _, o := range f.Operations {
+		fmt.Fprintf(bw, "%d %s %d %d %d %d %s %s\n", o.ID, o.Op, o.Layer, o.Row, o.Col, o.MineProximity, formatTime(o.CreatedAt), o.Player)
+	}
+	return bw.Flush()
+}
+
+// ReadText reads and validates a file in its compact text form
+func ReadText(r io.Reader) (File, error) {
+	f := File{}
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return f, ErrInvalidFile
+	}
+	header := strings.Fields(scanner.Text())
+	if len(header) != 2 || header[0] != Format {
+		return f, ErrInvalidFile
+	}
+	f.Format = header[0]
+	version, err := strconv.Atoi(header[1])
+	if err != nil {
+		return f, ErrInvalidFile
+	}
+	if version != Version && version != version1 {
+		return f, ErrUnsupportedVersion
+	}
+	f.Version = version
+	// fields
+	for {
+		if !scanner.Scan() {
+			return f, ErrInvalidFile
+		}
+		line := scanner.Text()
+		if line == "board" {
+			break
+		}
+		err = f.readField(line)
+		if err != nil {
+			return f, err
+		}
+	}
+	// board
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "operations" {
+			break
+		}
+		var row []int
+		if version == version1 {
+			row, err = version1Row(line)
+		} else {
+			row, err = readRow(line)
+		}
+		if err != nil {
+			return f, err
+		}
+		f.Board = append(f.Board, row)
+	}
+	// operations
+	for scanner.Scan() {
+		o, err := readOperation(scanner.Text())
+		if err != nil {
+			return f, err
+		}
+		f.Operations = append(f.Operations, o)
+	}
+	if scanner.Err() != nil {
+		return f, ErrInvalidFile
+	}
+	if version == version1 {
+		f.upgrade()
+	}
+	return f, f.Validate()
+}
+
+// upgrade converts the mine proximity values of a version 1 file to the current version
+func (f *File) upgrade() {
+	for _, row := range f.Board {
+		for i, p := range row {
+			row[i] = version1Point(p)
+		}
+	}
+	for i := range f.Operations {
+		f.Operations[i].MineProximity = version1Point(f.Operations[i].MineProximity)
+	}
+	f.Version = Version
+}
+
+func (f *File) readField(line string) error {
+	kv := strings.Fields(line)
+	if len(kv) != 2 {
+		return ErrInvalidFile
+	}
+	var err error
+	switch kv[0] {
+	case "layers":
+		f.Layers, err = strconv.Atoi(kv[1])
+	case "rows":
+		f.Rows, err = strconv.Atoi(kv[1])
+	case "cols":
+		f.Cols, err = strconv.Atoi(kv[1])
+	case "mines":
+		f.Mines, err = strconv.Atoi(kv[1])
+	case "topology":
+		f.Topology = kv[1]
+	case "wrap":
+		f.Wrap, err = strconv.ParseBool(kv[1])
+	case "seed":
+		f.Seed, err = strconv.ParseInt(kv[1], 10, 64)
+	case "generator":
+		f.GeneratorVersion, err = strconv.Atoi(kv[1])
+	case "noguess":
+		f.NoGuess, err = strconv.ParseBool(kv[1])
+	case "won":
+		f.Won, err = strconv.ParseBool(kv[1])
+	case "resigned":
+		f.Resigned, err = strconv.ParseBool(kv[1])
+	case "started":
+		f.StartedAt, err = parseTime(kv[1])
+	case "finished":
+		f.FinishedAt, err = parseTime(kv[1])
+	case "elapsed":
+		f.Elapsed, err = strconv.ParseInt(kv[1], 10, 64)
+	default:
+		// fields added by later versions are not known by this one
+		return ErrInvalidFile
+	}
+	if err != nil {
+		return ErrInvalidFile
+	}
+	return nil
+}
+
+func readRow(line string) ([]int, error) {
+	fields := strings.Fields(line)
+	row := make([]int, len(fields))
+	var err error
+	for i, field := range fields {
+		row[i], err = strconv.Atoi(field)
+		if err != nil {
+			return nil, ErrInvalidFile
+		}
+	}
+	return row, nil
+}
+
+func readOperation(line string) (Operation, error) {
+	o := Operation{}
+	// the player name is the rest of the line and it might have spaces
+	fields := strings.SplitN(line, " ", 8)
+	if len(fields) != 8 {
+		return o, ErrInvalidFile
+	}
+	values := make([]int, 5)
+	var err error
+	for i, j := range []int{0, 2, 3, 4, 5} {
+		values[i], err = strconv.Atoi(fields[j])
+		if err != nil {
+			return o, ErrInvalidFile
+		}
+	}
+	o.ID = values[0]
+	o.Op = fields[1]
+	o.Layer = values[1]
+	o.Row = values[2]
+	o.Col = values[3]
+	o.MineProximity = values[4]
+	o.CreatedAt, err = parseTime(fields[6])
+	if err != nil {
+		return o, ErrInvalidFile
+	}
+	o.Player = fields[7]
+	return o, nil
+}
+
+func formatTime(t null.Time) string {
+	if !t.Valid {
+		return "-"
+	}
+	return t.Time.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(s string) (null.Time, error) {
+	if s == "-" {
+		return null.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return null.Time{}, err
+	}
+	return null.TimeFrom(t), nil
+}
+
+// version1Row reads a board row of a version 1 text file, which has a single character per point
+func version1Row(line string) ([]int, error) {
+	row := make([]int, len(line))
+	var err error
+	for i := 0; i < len(line); i++ {
+		row[i], err = version1Char(line[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// version1Char returns the version 1 mine proximity value of a character of a version 1 text file
+func version1Char(c byte) (int, error) {
+	if c >= '0' && c <= '9' {
+		return int(c - '0'), nil
+	} else if c >= 'a' && c <= 'j' {
+		return -int(c-'a') - 1, nil
+	} else if c >= 'A' && c <= 'J' {
+		return -int(c-'A') - 11, nil
+	} else if c >= 'K' && c <= 'T' {
+		return -int(c-'K') - 21, nil
+	}
+	return 0, ErrInvalidFile
+}
+
+// version1Point converts a version 1 mine proximity value, where revealed mines were 9 and every state was 10
+// values apart, to the current one. Values out of the version 1 range become an invalid value.
+func version1Point(p int) int {
+	switch {
+	case p >= 0 && p <= 8:
+		return p
+	case p == 9:
+		return algebra.RevealedMine
+	case p == -10:
+		return algebra.UnrevealedMine
+	case p >= -9 && p <= -1:
+		return p
+	case p >= -20 && p <= -11:
+		return version1Point(p+10) - algebra.MarkOffset
+	case p >= -30 && p <= -21:
+		return version1Point(p+20) - 2*algebra.MarkOffset
+	}
+	return algebra.MarkedMine - 1
+}
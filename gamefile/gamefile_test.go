@@ -0,0 +1,203 @@
+package gamefile
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/volatiletech/null"
+)
+
+func testFile() File {
+	f := New()
+	f.Layers = 1
+	f.Rows = 2
+	f.Cols = 3
+	f.Mines = 2
+	f.Topology = "square"
+	f.Seed = 42
+	f.GeneratorVersion = 1
+	f.Won = true
+	f.StartedAt = null.TimeFrom(time.Date(2019, time.March, 1, 10, 0, 0, 0, time.UTC))
+	f.FinishedAt = null.TimeFrom(time.Date(2019, time.March, 1, 10, 1, 0, 500, time.UTC))
+	f.Elapsed = 60000
+	f.Board = [][]int{
+		{1, 2, -84},
+		{-56, -2, -29},
+	}
+	f.Operations = []Operation{
+		{ID: 1, Op: "reveal", Row: 0, Col: 0, MineProximity: 1, CreatedAt: f.StartedAt, Player: "a player"},
+		{ID: 3, Op: "mark", Row: 1, Col: 0, MineProximity: -56, Player: "another"},
+	}
+	return f
+}
+
+func TestText(t *testing.T) {
+	f := testFile()
+	var buf bytes.Buffer
+	err := WriteText(&buf, f)
+	if err != nil {
+		t.Fatalf("error writing file: %v\n", err)
+	}
+	if !strings.Contains(buf.String(), "\nboard\n1 2 -84\n-56 -2 -29\noperations\n") {
+		t.Fatalf("expected the board to be written with one value per point but was:\n%s", buf.String())
+	}
+	read, err := ReadText(&buf)
+	if err != nil {
+		t.Fatalf("error reading file: %v\n", err)
+	}
+	if !reflect.DeepEqual(read, f) {
+		t.Fatalf("expected file %v but got %v\n", f, read)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	f := testFile()
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("error writing file: %v\n", err)
+	}
+	read, err := ReadJSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("error reading file: %v\n", err)
+	}
+	if !reflect.DeepEqual(read.Board, f.Board) || !reflect.DeepEqual(read.Operations[1], f.Operations[1]) || !read.FinishedAt.Time.Equal(f.FinishedAt.Time) {
+		t.Fatalf("expected file %v but got %v\n", f, read)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testTable := []struct {
+		change func(f *File)
+		err    error
+	}{
+		{
+			change: func(f *File) {},
+		},
+		{
+			change: func(f *File) { f.Format = "chess" },
+			err:    ErrInvalidFile,
+		},
+		{
+			change: func(f *File) { f.Version = Version + 1 },
+			err:    ErrUnsupportedVersion,
+		},
+		{
+			change: func(f *File) { f.FinishedAt = null.Time{} },
+			err:    ErrInvalidFile,
+		},
+		{
+			change: func(f *File) { f.Mines = 3 },
+			err:    ErrInvalidFile,
+		},
+		{
+			change: func(f *File) { f.Board[1] = []int{-1, -1} },
+			err:    ErrInvalidFile,
+		},
+		{
+			change: func(f *File) { f.Board[0][0] = algebra.RevealedMine + 1 },
+			err:    ErrInvalidFile,
+		},
+		{
+			change: func(f *File) { f.Board[0][0] = algebra.MarkedMine - 1 },
+			err:    ErrInvalidFile,
+		},
+		{
+			change: func(f *File) { f.Board[0][0] = algebra.MaxMineProximity },
+		},
+		{
+			change: func(f *File) { f.Operations[1].ID = 1 },
+			err:    ErrInvalidFile,
+		},
+		{
+			change: func(f *File) { f.Operations[0].Row = 2 },
+			err:    ErrInvalidFile,
+		},
+	}
+	for i, test := range testTable {
+		f := testFile()
+		test.change(&f)
+		err := f.Validate()
+		if err != test.err {
+			t.Fatalf("test %d failed: expected err %v but got %v\n", i, test.err, err)
+		}
+	}
+}
+
+func TestReadInvalidText(t *testing.T) {
+	testTable := []struct {
+		text string
+		err  error
+	}{
+		{
+			text: "",
+			err:  ErrInvalidFile,
+		},
+		{
+			text: "minesweeper-game 3\n",
+			err:  ErrUnsupportedVersion,
+		},
+		{
+			text: "minesweeper-game 1\nlayers one\n",
+			err:  ErrInvalidFile,
+		},
+		{
+			text: "minesweeper-game 1\nlayers 1\nrows 1\ncols 2\nmines 1\nfinished 2019-03-01T10:01:00Z\nboard\n1?\noperations\n",
+			err:  ErrInvalidFile,
+		},
+		{
+			text: "minesweeper-game 1\nlayers 1\nrows 1\ncols 2\nmines 1\nfinished 2019-03-01T10:01:00Z\nboard\n1j\noperations\n1 reveal 0 0\n",
+			err:  ErrInvalidFile,
+		},
+		{
+			text: "minesweeper-game 1\nlayers 1\nrows 1\ncols 2\nmines 1\nfinished 2019-03-01T10:01:00Z\nboard\n1j\noperations\n",
+		},
+		{
+			text: "minesweeper-game 2\nlayers 1\nrows 1\ncols 2\nmines 1\nfinished 2019-03-01T10:01:00Z\nboard\n1 x\noperations\n",
+			err:  ErrInvalidFile,
+		},
+		{
+			text: "minesweeper-game 2\nlayers 1\nrows 1\ncols 2\nmines 1\nfinished 2019-03-01T10:01:00Z\nboard\n1 -10\noperations\n",
+			err:  ErrInvalidFile,
+		},
+		{
+			text: "minesweeper-game 2\nlayers 1\nrows 1\ncols 2\nmines 1\nfinished 2019-03-01T10:01:00Z\nboard\n1 -28\noperations\n",
+		},
+	}
+	for i, test := range testTable {
+		_, err := ReadText(strings.NewReader(test.text))
+		if err != test.err {
+			t.Fatalf("test %d failed: expected err %v but got %v\n", i, test.err, err)
+		}
+	}
+}
+
+func TestReadVersion1(t *testing.T) {
+	text := "minesweeper-game 1\nlayers 1\nrows 2\ncols 3\nmines 3\nfinished 2019-03-01T10:01:00Z\nboard\n19T\nJbA\noperations\n1 mark 0 1 0 -20 - a player\n2 reveal 0 0 1 9 - a player\n"
+	expected := [][]int{
+		{1, algebra.RevealedMine, algebra.MarkedMine},
+		{algebra.SuspectMine, -2, -1 - algebra.MarkOffset},
+	}
+	f, err := ReadText(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("error reading file: %v\n", err)
+	}
+	if f.Version != Version || !reflect.DeepEqual(f.Board, expected) {
+		t.Fatalf("expected a version %d file with board %v but got version %d with board %v\n", Version, expected, f.Version, f.Board)
+	}
+	if f.Operations[0].MineProximity != algebra.SuspectMine || f.Operations[1].MineProximity != algebra.RevealedMine {
+		t.Fatalf("expected the operations to be converted but got %v\n", f.Operations)
+	}
+	data := `{"format":"minesweeper-game","version":1,"layers":1,"rows":1,"cols":2,"mines":1,"finishedAt":"2019-03-01T10:01:00Z","board":[[-1,-10]]}`
+	f, err = ReadJSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("error reading file: %v\n", err)
+	}
+	if f.Version != Version || f.Board[0][0] != -1 || f.Board[0][1] != algebra.UnrevealedMine {
+		t.Fatalf("expected the board to be converted but got version %d with board %v\n", f.Version, f.Board)
+	}
+}
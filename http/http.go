@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -57,6 +58,13 @@ func httpErrorHandlerFactory(logger *log.Logger) echo.HTTPErrorHandler {
 	}
 }
 
+// streamSkipper skips compressing websocket connections and event streams, their messages must be sent as soon
+// as they are written
+func streamSkipper(c echo.Context) bool {
+	req := c.Request()
+	return strings.EqualFold(req.Header.Get(echo.HeaderUpgrade), "websocket") || strings.Contains(req.Header.Get(echo.HeaderAccept), "text/event-stream")
+}
+
 // Serve http connections
 func Serve(cnf Config, logger *log.Logger, db *sql.DB) error {
 	router := echo.New()
@@ -66,7 +74,9 @@ func Serve(cnf Config, logger *log.Logger, db *sql.DB) error {
 	router.Use(middleware.Recover())
 	router.Use(middleware.Secure())
 	router.Use(middleware.BodyLimit("1M"))
-	router.Use(middleware.Gzip())
+	router.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+		Skipper: streamSkipper,
+	}))
 	initRoutes(router, cnf.JWTSecret, logger, db)
 	srv := newServer(router, cnf.Address)
 	go func() {
@@ -104,3 +104,31 @@ func NewResponseFromError(c echo.Context, err error) error {
 	code, message := NewHTTPError(err)
 	return NewErrorResponse(c, code, message)
 }
+
+// NewSuccessMessage creates a successful response that is sent outside of an http response, like a websocket
+// message
+func NewSuccessMessage(data interface{}) ServiceResponse {
+	return ServiceResponse{
+		Status: Status{
+			Error:   false,
+			Code:    http.StatusOK,
+			Message: successMessage,
+			Version: minesweeperVersion,
+		},
+		Data: data,
+	}
+}
+
+// NewErrorMessage creates an error response from an Error that is sent outside of an http response, like a
+// websocket message
+func NewErrorMessage(err error) ServiceResponse {
+	code, message := NewHTTPError(err)
+	return ServiceResponse{
+		Status: Status{
+			Error:   true,
+			Code:    code,
+			Message: message,
+			Version: minesweeperVersion,
+		},
+	}
+}
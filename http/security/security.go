@@ -2,6 +2,7 @@ package security
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -13,6 +14,8 @@ const (
 	contextKey = "jwtUser"
 	userID     = "id"
 	userName   = "name"
+	// tokenParam is the query param with the token of a websocket connection or an event stream
+	tokenParam = "token"
 )
 
 // ErrUserNotFound is returned when a jwt token was not found in the request context
@@ -30,9 +33,23 @@ func JWTMiddlewareFactory(jwtSecret string) echo.MiddlewareFunc {
 	return middleware.JWTWithConfig(middleware.JWTConfig{
 		SigningKey: []byte(jwtSecret),
 		ContextKey: contextKey,
+		BeforeFunc: streamToken,
 	})
 }
 
+// streamToken moves the token of a websocket connection or an event stream from the query to the Authorization
+// header because browsers cannot set headers on them
+func streamToken(c echo.Context) {
+	req := c.Request()
+	token := c.QueryParam(tokenParam)
+	if token == "" || req.Header.Get(echo.HeaderAuthorization) != "" {
+		return
+	}
+	if strings.EqualFold(req.Header.Get(echo.HeaderUpgrade), "websocket") || strings.Contains(req.Header.Get(echo.HeaderAccept), "text/event-stream") {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+}
+
 // JWTEncode encodes a user into a jwt.MapClaims
 func JWTEncode(user JWTUser, d time.Duration) jwt.MapClaims {
 	claims := jwt.MapClaims{}
@@ -1,5 +1,7 @@
 import { faCog, faQuestion, faFlag } from "@fortawesome/free-solid-svg-icons";
 
+const REVEALED_MINE = 27;
+
 export default {
   props: {
     row: {
@@ -16,7 +18,7 @@ export default {
   },
   computed: {
     icon() {
-      if (this.mineProximity === 9) {
+      if (this.mineProximity === REVEALED_MINE) {
         return faCog;
       } else if (this.mineProximity === "?") {
         return faQuestion;
@@ -26,7 +28,7 @@ export default {
       return null;
     },
     isMine() {
-      return this.mineProximity === 9;
+      return this.mineProximity === REVEALED_MINE;
     },
     isRevealed() {
       return typeof this.mineProximity === "number";
@@ -37,7 +39,7 @@ export default {
     mineProximityClass() {
       if (this.mineProximity === null) {
         return "mine-unrevealed";
-      } else if (this.mineProximity < 9) {
+      } else if (this.mineProximity < REVEALED_MINE) {
         return `mine-${this.mineProximity}`;
       }
     }
@@ -4,13 +4,19 @@
 package models
 
 var TableNames = struct {
-	GameBoardPoints string
-	GameOperations  string
-	Games           string
-	Players         string
+	GameBoardPoints  string
+	GameOperations   string
+	GamePresets      string
+	GameParticipants string
+	GameTurns        string
+	Games            string
+	Players          string
 }{
-	GameBoardPoints: "game_board_points",
-	GameOperations:  "game_operations",
-	Games:           "games",
-	Players:         "players",
+	GameBoardPoints:  "game_board_points",
+	GameOperations:   "game_operations",
+	GamePresets:      "game_presets",
+	GameParticipants: "game_participants",
+	GameTurns:        "game_turns",
+	Games:            "games",
+	Players:          "players",
 }
@@ -51,8 +51,24 @@ func makeCacheKey(cols boil.Columns, nzDefaults []string) string {
 	return str
 }
 
+// Enum values for board_topology
+const (
+	BoardTopologySquare = "square"
+	BoardTopologyHex    = "hex"
+)
+
 // Enum values for mine_operation
 const (
 	MineOperationReveal = "reveal"
 	MineOperationMark   = "mark"
+	MineOperationChord  = "chord"
+	MineOperationHint   = "hint"
+	MineOperationUndo   = "undo"
+)
+
+// Enum values for participant_role
+const (
+	ParticipantRoleOwner     = "owner"
+	ParticipantRolePlayer    = "player"
+	ParticipantRoleSpectator = "spectator"
 )
@@ -31,6 +31,7 @@ type GameBoardPoint struct {
 	MineProximity int16            `boil:"mine_proximity" json:"mineProximity" toml:"mineProximity" yaml:"mineProximity"`
 	CreatedAt     null.Time        `boil:"created_at" json:"createdAt,omitempty" toml:"createdAt" yaml:"createdAt,omitempty"`
 	UpdatedAt     null.Time        `boil:"updated_at" json:"updatedAt,omitempty" toml:"updatedAt" yaml:"updatedAt,omitempty"`
+	Layer         int16            `boil:"layer" json:"layer" toml:"layer" yaml:"layer"`
 	R             *gameBoardPointR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L             gameBoardPointL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
@@ -43,6 +44,7 @@ var GameBoardPointColumns = struct {
 	MineProximity string
 	CreatedAt     string
 	UpdatedAt     string
+	Layer         string
 }{
 	ID:            "id",
 	GameID:        "game_id",
@@ -51,6 +53,7 @@ var GameBoardPointColumns = struct {
 	MineProximity: "mine_proximity",
 	CreatedAt:     "created_at",
 	UpdatedAt:     "updated_at",
+	Layer:         "layer",
 }
 
 // Generated where
@@ -104,6 +107,7 @@ var GameBoardPointWhere = struct {
 	MineProximity whereHelperint16
 	CreatedAt     whereHelpernull_Time
 	UpdatedAt     whereHelpernull_Time
+	Layer         whereHelperint16
 }{
 	ID:            whereHelperint64{field: `id`},
 	GameID:        whereHelperint64{field: `game_id`},
@@ -112,6 +116,7 @@ var GameBoardPointWhere = struct {
 	MineProximity: whereHelperint16{field: `mine_proximity`},
 	CreatedAt:     whereHelpernull_Time{field: `created_at`},
 	UpdatedAt:     whereHelpernull_Time{field: `updated_at`},
+	Layer:         whereHelperint16{field: `layer`},
 }
 
 // GameBoardPointRels is where relationship names are stored.
@@ -135,9 +140,9 @@ func (*gameBoardPointR) NewStruct() *gameBoardPointR {
 type gameBoardPointL struct{}
 
 var (
-	gameBoardPointColumns               = []string{"id", "game_id", "row", "col", "mine_proximity", "created_at", "updated_at"}
+	gameBoardPointColumns               = []string{"id", "game_id", "row", "col", "mine_proximity", "created_at", "updated_at", "layer"}
 	gameBoardPointColumnsWithoutDefault = []string{"game_id", "row", "col", "mine_proximity", "created_at", "updated_at"}
-	gameBoardPointColumnsWithDefault    = []string{"id"}
+	gameBoardPointColumnsWithDefault    = []string{"id", "layer"}
 	gameBoardPointPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 	"github.com/volatiletech/sqlboiler/queries"
 	"github.com/volatiletech/sqlboiler/queries/qm"
@@ -30,7 +31,10 @@ type GameOperation struct {
 	Operation     string          `boil:"operation" json:"operation" toml:"operation" yaml:"operation"`
 	Row           int16           `boil:"row" json:"row" toml:"row" yaml:"row"`
 	Col           int16           `boil:"col" json:"col" toml:"col" yaml:"col"`
+	Layer         int16           `boil:"layer" json:"layer" toml:"layer" yaml:"layer"`
 	MineProximity int16           `boil:"mine_proximity" json:"mineProximity" toml:"mineProximity" yaml:"mineProximity"`
+	CreatedAt     null.Time       `boil:"created_at" json:"createdAt,omitempty" toml:"createdAt" yaml:"createdAt,omitempty"`
+	Score         int             `boil:"score" json:"score" toml:"score" yaml:"score"`
 	R             *gameOperationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L             gameOperationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
@@ -43,7 +47,10 @@ var GameOperationColumns = struct {
 	Operation     string
 	Row           string
 	Col           string
+	Layer         string
 	MineProximity string
+	CreatedAt     string
+	Score         string
 }{
 	ID:            "id",
 	GameID:        "game_id",
@@ -52,7 +59,10 @@ var GameOperationColumns = struct {
 	Operation:     "operation",
 	Row:           "row",
 	Col:           "col",
+	Layer:         "layer",
 	MineProximity: "mine_proximity",
+	CreatedAt:     "created_at",
+	Score:         "score",
 }
 
 // Generated where
@@ -83,7 +93,10 @@ var GameOperationWhere = struct {
 	Operation     whereHelperstring
 	Row           whereHelperint16
 	Col           whereHelperint16
+	Layer         whereHelperint16
 	MineProximity whereHelperint16
+	CreatedAt     whereHelpernull_Time
+	Score         whereHelperint
 }{
 	ID:            whereHelperint64{field: `id`},
 	GameID:        whereHelperint64{field: `game_id`},
@@ -92,7 +105,10 @@ var GameOperationWhere = struct {
 	Operation:     whereHelperstring{field: `operation`},
 	Row:           whereHelperint16{field: `row`},
 	Col:           whereHelperint16{field: `col`},
+	Layer:         whereHelperint16{field: `layer`},
 	MineProximity: whereHelperint16{field: `mine_proximity`},
+	CreatedAt:     whereHelpernull_Time{field: `created_at`},
+	Score:         whereHelperint{field: `score`},
 }
 
 // GameOperationRels is where relationship names are stored.
@@ -119,9 +135,9 @@ func (*gameOperationR) NewStruct() *gameOperationR {
 type gameOperationL struct{}
 
 var (
-	gameOperationColumns               = []string{"id", "game_id", "player_id", "operation_id", "operation", "row", "col", "mine_proximity"}
-	gameOperationColumnsWithoutDefault = []string{"game_id", "player_id", "operation_id", "operation", "row", "col", "mine_proximity"}
-	gameOperationColumnsWithDefault    = []string{"id"}
+	gameOperationColumns               = []string{"id", "game_id", "player_id", "operation_id", "operation", "row", "col", "layer", "mine_proximity", "created_at", "score"}
+	gameOperationColumnsWithoutDefault = []string{"game_id", "player_id", "operation_id", "operation", "row", "col", "mine_proximity", "created_at"}
+	gameOperationColumnsWithDefault    = []string{"id", "layer", "score"}
 	gameOperationPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -564,6 +580,13 @@ func (o *GameOperation) Insert(ctx context.Context, exec boil.ContextExecutor, c
 	}
 
 	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+	}
 
 	nzDefaults := queries.NonZeroDefaultSet(gameOperationColumnsWithDefault, o)
 
@@ -761,6 +784,13 @@ func (o *GameOperation) Upsert(ctx context.Context, exec boil.ContextExecutor, u
 	if o == nil {
 		return errors.New("models: no game_operations provided for upsert")
 	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+	}
 
 	nzDefaults := queries.NonZeroDefaultSet(gameOperationColumnsWithDefault, o)
 
@@ -24,45 +24,105 @@ import (
 
 // Game is an object representing the database table.
 type Game struct {
-	ID         int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Private    bool      `boil:"private" json:"private" toml:"private" yaml:"private"`
-	Cols       int16     `boil:"cols" json:"cols" toml:"cols" yaml:"cols"`
-	Rows       int16     `boil:"rows" json:"rows" toml:"rows" yaml:"rows"`
-	Mines      int16     `boil:"mines" json:"mines" toml:"mines" yaml:"mines"`
-	StartedAt  null.Time `boil:"started_at" json:"startedAt,omitempty" toml:"startedAt" yaml:"startedAt,omitempty"`
-	FinishedAt null.Time `boil:"finished_at" json:"finishedAt,omitempty" toml:"finishedAt" yaml:"finishedAt,omitempty"`
-	Won        null.Bool `boil:"won" json:"won,omitempty" toml:"won" yaml:"won,omitempty"`
-	CreatorID  int64     `boil:"creator_id" json:"creatorID" toml:"creatorID" yaml:"creatorID"`
-	CreatedAt  null.Time `boil:"created_at" json:"createdAt,omitempty" toml:"createdAt" yaml:"createdAt,omitempty"`
-	UpdatedAt  null.Time `boil:"updated_at" json:"updatedAt,omitempty" toml:"updatedAt" yaml:"updatedAt,omitempty"`
-	R          *gameR    `boil:"-" json:"-" toml:"-" yaml:"-"`
-	L          gameL     `boil:"-" json:"-" toml:"-" yaml:"-"`
+	ID               int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Private          bool        `boil:"private" json:"private" toml:"private" yaml:"private"`
+	Cols             int16       `boil:"cols" json:"cols" toml:"cols" yaml:"cols"`
+	Rows             int16       `boil:"rows" json:"rows" toml:"rows" yaml:"rows"`
+	Mines            int16       `boil:"mines" json:"mines" toml:"mines" yaml:"mines"`
+	StartedAt        null.Time   `boil:"started_at" json:"startedAt,omitempty" toml:"startedAt" yaml:"startedAt,omitempty"`
+	FinishedAt       null.Time   `boil:"finished_at" json:"finishedAt,omitempty" toml:"finishedAt" yaml:"finishedAt,omitempty"`
+	Won              null.Bool   `boil:"won" json:"won,omitempty" toml:"won" yaml:"won,omitempty"`
+	CreatorID        int64       `boil:"creator_id" json:"creatorID" toml:"creatorID" yaml:"creatorID"`
+	CreatedAt        null.Time   `boil:"created_at" json:"createdAt,omitempty" toml:"createdAt" yaml:"createdAt,omitempty"`
+	UpdatedAt        null.Time   `boil:"updated_at" json:"updatedAt,omitempty" toml:"updatedAt" yaml:"updatedAt,omitempty"`
+	SafeFirstReveal  bool        `boil:"safe_first_reveal" json:"safeFirstReveal" toml:"safeFirstReveal" yaml:"safeFirstReveal"`
+	Seed             int64       `boil:"seed" json:"seed" toml:"seed" yaml:"seed"`
+	GeneratorVersion int16       `boil:"generator_version" json:"generatorVersion" toml:"generatorVersion" yaml:"generatorVersion"`
+	NoGuess          bool        `boil:"no_guess" json:"noGuess" toml:"noGuess" yaml:"noGuess"`
+	Topology         string      `boil:"topology" json:"topology" toml:"topology" yaml:"topology"`
+	Wrap             bool        `boil:"wrap" json:"wrap" toml:"wrap" yaml:"wrap"`
+	Layers           int16       `boil:"layers" json:"layers" toml:"layers" yaml:"layers"`
+	Preset           null.String `boil:"preset" json:"preset,omitempty" toml:"preset" yaml:"preset,omitempty"`
+	PausedAt         null.Time   `boil:"paused_at" json:"pausedAt,omitempty" toml:"pausedAt" yaml:"pausedAt,omitempty"`
+	PausedMillis     int64       `boil:"paused_millis" json:"pausedMillis" toml:"pausedMillis" yaml:"pausedMillis"`
+	Casual           bool        `boil:"casual" json:"casual" toml:"casual" yaml:"casual"`
+	Undos            int         `boil:"undos" json:"undos" toml:"undos" yaml:"undos"`
+	Resigned         bool        `boil:"resigned" json:"resigned" toml:"resigned" yaml:"resigned"`
+	Archived         bool        `boil:"archived" json:"archived" toml:"archived" yaml:"archived"`
+	SpectateOnly     bool        `boil:"spectate_only" json:"spectateOnly" toml:"spectateOnly" yaml:"spectateOnly"`
+	Competitive      bool        `boil:"competitive" json:"competitive" toml:"competitive" yaml:"competitive"`
+	TurnBased        bool        `boil:"turn_based" json:"turnBased" toml:"turnBased" yaml:"turnBased"`
+	TurnSeconds      int         `boil:"turn_seconds" json:"turnSeconds" toml:"turnSeconds" yaml:"turnSeconds"`
+	TurnPosition     int         `boil:"turn_position" json:"turnPosition" toml:"turnPosition" yaml:"turnPosition"`
+	TurnStartedAt    null.Time   `boil:"turn_started_at" json:"turnStartedAt,omitempty" toml:"turnStartedAt" yaml:"turnStartedAt,omitempty"`
+	R                *gameR      `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L                gameL       `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var GameColumns = struct {
-	ID         string
-	Private    string
-	Cols       string
-	Rows       string
-	Mines      string
-	StartedAt  string
-	FinishedAt string
-	Won        string
-	CreatorID  string
-	CreatedAt  string
-	UpdatedAt  string
+	ID               string
+	Private          string
+	Cols             string
+	Rows             string
+	Mines            string
+	StartedAt        string
+	FinishedAt       string
+	Won              string
+	CreatorID        string
+	CreatedAt        string
+	UpdatedAt        string
+	SafeFirstReveal  string
+	Seed             string
+	GeneratorVersion string
+	NoGuess          string
+	Topology         string
+	Wrap             string
+	Layers           string
+	Preset           string
+	PausedAt         string
+	PausedMillis     string
+	Casual           string
+	Undos            string
+	Resigned         string
+	Archived         string
+	SpectateOnly     string
+	Competitive      string
+	TurnBased        string
+	TurnSeconds      string
+	TurnPosition     string
+	TurnStartedAt    string
 }{
-	ID:         "id",
-	Private:    "private",
-	Cols:       "cols",
-	Rows:       "rows",
-	Mines:      "mines",
-	StartedAt:  "started_at",
-	FinishedAt: "finished_at",
-	Won:        "won",
-	CreatorID:  "creator_id",
-	CreatedAt:  "created_at",
-	UpdatedAt:  "updated_at",
+	ID:               "id",
+	Private:          "private",
+	Cols:             "cols",
+	Rows:             "rows",
+	Mines:            "mines",
+	StartedAt:        "started_at",
+	FinishedAt:       "finished_at",
+	Won:              "won",
+	CreatorID:        "creator_id",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	SafeFirstReveal:  "safe_first_reveal",
+	Seed:             "seed",
+	GeneratorVersion: "generator_version",
+	NoGuess:          "no_guess",
+	Topology:         "topology",
+	Wrap:             "wrap",
+	Layers:           "layers",
+	Preset:           "preset",
+	PausedAt:         "paused_at",
+	PausedMillis:     "paused_millis",
+	Casual:           "casual",
+	Undos:            "undos",
+	Resigned:         "resigned",
+	Archived:         "archived",
+	SpectateOnly:     "spectate_only",
+	Competitive:      "competitive",
+	TurnBased:        "turn_based",
+	TurnSeconds:      "turn_seconds",
+	TurnPosition:     "turn_position",
+	TurnStartedAt:    "turn_started_at",
 }
 
 // Generated where
@@ -99,48 +159,117 @@ func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var GameWhere = struct {
-	ID         whereHelperint64
-	Private    whereHelperbool
-	Cols       whereHelperint16
-	Rows       whereHelperint16
-	Mines      whereHelperint16
-	StartedAt  whereHelpernull_Time
-	FinishedAt whereHelpernull_Time
-	Won        whereHelpernull_Bool
-	CreatorID  whereHelperint64
-	CreatedAt  whereHelpernull_Time
-	UpdatedAt  whereHelpernull_Time
+	ID               whereHelperint64
+	Private          whereHelperbool
+	Cols             whereHelperint16
+	Rows             whereHelperint16
+	Mines            whereHelperint16
+	StartedAt        whereHelpernull_Time
+	FinishedAt       whereHelpernull_Time
+	Won              whereHelpernull_Bool
+	CreatorID        whereHelperint64
+	CreatedAt        whereHelpernull_Time
+	UpdatedAt        whereHelpernull_Time
+	SafeFirstReveal  whereHelperbool
+	Seed             whereHelperint64
+	GeneratorVersion whereHelperint16
+	NoGuess          whereHelperbool
+	Topology         whereHelperstring
+	Wrap             whereHelperbool
+	Layers           whereHelperint16
+	Preset           whereHelpernull_String
+	PausedAt         whereHelpernull_Time
+	PausedMillis     whereHelperint64
+	Casual           whereHelperbool
+	Undos            whereHelperint
+	Resigned         whereHelperbool
+	Archived         whereHelperbool
+	SpectateOnly     whereHelperbool
+	Competitive      whereHelperbool
+	TurnBased        whereHelperbool
+	TurnSeconds      whereHelperint
+	TurnPosition     whereHelperint
+	TurnStartedAt    whereHelpernull_Time
 }{
-	ID:         whereHelperint64{field: `id`},
-	Private:    whereHelperbool{field: `private`},
-	Cols:       whereHelperint16{field: `cols`},
-	Rows:       whereHelperint16{field: `rows`},
-	Mines:      whereHelperint16{field: `mines`},
-	StartedAt:  whereHelpernull_Time{field: `started_at`},
-	FinishedAt: whereHelpernull_Time{field: `finished_at`},
-	Won:        whereHelpernull_Bool{field: `won`},
-	CreatorID:  whereHelperint64{field: `creator_id`},
-	CreatedAt:  whereHelpernull_Time{field: `created_at`},
-	UpdatedAt:  whereHelpernull_Time{field: `updated_at`},
+	ID:               whereHelperint64{field: `id`},
+	Private:          whereHelperbool{field: `private`},
+	Cols:             whereHelperint16{field: `cols`},
+	Rows:             whereHelperint16{field: `rows`},
+	Mines:            whereHelperint16{field: `mines`},
+	StartedAt:        whereHelpernull_Time{field: `started_at`},
+	FinishedAt:       whereHelpernull_Time{field: `finished_at`},
+	Won:              whereHelpernull_Bool{field: `won`},
+	CreatorID:        whereHelperint64{field: `creator_id`},
+	CreatedAt:        whereHelpernull_Time{field: `created_at`},
+	UpdatedAt:        whereHelpernull_Time{field: `updated_at`},
+	SafeFirstReveal:  whereHelperbool{field: `safe_first_reveal`},
+	Seed:             whereHelperint64{field: `seed`},
+	GeneratorVersion: whereHelperint16{field: `generator_version`},
+	NoGuess:          whereHelperbool{field: `no_guess`},
+	Topology:         whereHelperstring{field: `topology`},
+	Wrap:             whereHelperbool{field: `wrap`},
+	Layers:           whereHelperint16{field: `layers`},
+	Preset:           whereHelpernull_String{field: `preset`},
+	PausedAt:         whereHelpernull_Time{field: `paused_at`},
+	PausedMillis:     whereHelperint64{field: `paused_millis`},
+	Casual:           whereHelperbool{field: `casual`},
+	Undos:            whereHelperint{field: `undos`},
+	Resigned:         whereHelperbool{field: `resigned`},
+	Archived:         whereHelperbool{field: `archived`},
+	SpectateOnly:     whereHelperbool{field: `spectate_only`},
+	Competitive:      whereHelperbool{field: `competitive`},
+	TurnBased:        whereHelperbool{field: `turn_based`},
+	TurnSeconds:      whereHelperint{field: `turn_seconds`},
+	TurnPosition:     whereHelperint{field: `turn_position`},
+	TurnStartedAt:    whereHelpernull_Time{field: `turn_started_at`},
 }
 
 // GameRels is where relationship names are stored.
 var GameRels = struct {
-	Creator         string
-	GameBoardPoints string
-	GameOperations  string
+	Creator          string
+	GameBoardPoints  string
+	GameOperations   string
+	GameParticipants string
+	GameTurns        string
 }{
-	Creator:         "Creator",
-	GameBoardPoints: "GameBoardPoints",
-	GameOperations:  "GameOperations",
+	Creator:          "Creator",
+	GameBoardPoints:  "GameBoardPoints",
+	GameOperations:   "GameOperations",
+	GameParticipants: "GameParticipants",
+	GameTurns:        "GameTurns",
 }
 
 // gameR is where relationships are stored.
 type gameR struct {
-	Creator         *Player
-	GameBoardPoints GameBoardPointSlice
-	GameOperations  GameOperationSlice
+	Creator          *Player
+	GameBoardPoints  GameBoardPointSlice
+	GameOperations   GameOperationSlice
+	GameParticipants GameParticipantSlice
+	GameTurns        GameTurnSlice
 }
 
 // NewStruct creates a new relationship struct
@@ -152,9 +281,9 @@ func (*gameR) NewStruct() *gameR {
 type gameL struct{}
 
 var (
-	gameColumns               = []string{"id", "private", "cols", "rows", "mines", "started_at", "finished_at", "won", "creator_id", "created_at", "updated_at"}
-	gameColumnsWithoutDefault = []string{"cols", "rows", "mines", "started_at", "finished_at", "creator_id", "created_at", "updated_at"}
-	gameColumnsWithDefault    = []string{"id", "private", "won"}
+	gameColumns               = []string{"id", "private", "cols", "rows", "mines", "started_at", "finished_at", "won", "creator_id", "created_at", "updated_at", "safe_first_reveal", "seed", "generator_version", "no_guess", "topology", "wrap", "layers", "preset", "paused_at", "paused_millis", "casual", "undos", "resigned", "archived", "spectate_only", "competitive", "turn_based", "turn_seconds", "turn_position", "turn_started_at"}
+	gameColumnsWithoutDefault = []string{"cols", "rows", "mines", "started_at", "finished_at", "creator_id", "created_at", "updated_at", "seed", "generator_version", "preset", "paused_at", "turn_started_at"}
+	gameColumnsWithDefault    = []string{"id", "private", "won", "safe_first_reveal", "no_guess", "topology", "wrap", "layers", "paused_millis", "casual", "undos", "resigned", "archived", "spectate_only", "competitive", "turn_based", "turn_seconds", "turn_position"}
 	gamePrimaryKeyColumns     = []string{"id"}
 )
 
@@ -305,6 +434,48 @@ func (o *Game) GameOperations(mods ...qm.QueryMod) gameOperationQuery {
 	return query
 }
 
+// GameParticipants retrieves all the game_participant's GameParticipants with an executor.
+func (o *Game) GameParticipants(mods ...qm.QueryMod) gameParticipantQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"game_participants\".\"game_id\"=?", o.ID),
+	)
+
+	query := GameParticipants(queryMods...)
+	queries.SetFrom(query.Query, "\"game_participants\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"game_participants\".*"})
+	}
+
+	return query
+}
+
+// GameTurns retrieves all the game_turn's GameTurns with an executor.
+func (o *Game) GameTurns(mods ...qm.QueryMod) gameTurnQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"game_turns\".\"game_id\"=?", o.ID),
+	)
+
+	query := GameTurns(queryMods...)
+	queries.SetFrom(query.Query, "\"game_turns\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"game_turns\".*"})
+	}
+
+	return query
+}
+
 // LoadCreator allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (gameL) LoadCreator(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGame interface{}, mods queries.Applicator) error {
@@ -574,6 +745,182 @@ func (gameL) LoadGameOperations(ctx context.Context, e boil.ContextExecutor, sin
 	return nil
 }
 
+// LoadGameParticipants allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (gameL) LoadGameParticipants(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGame interface{}, mods queries.Applicator) error {
+	var slice []*Game
+	var object *Game
+
+	if singular {
+		object = maybeGame.(*Game)
+	} else {
+		slice = *maybeGame.(*[]*Game)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &gameR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &gameR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`game_participants`), qm.WhereIn(`game_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load game_participants")
+	}
+
+	var resultSlice []*GameParticipant
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice game_participants")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on game_participants")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for game_participants")
+	}
+
+	if singular {
+		object.R.GameParticipants = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &gameParticipantR{}
+			}
+			foreign.R.Game = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.GameID {
+				local.R.GameParticipants = append(local.R.GameParticipants, foreign)
+				if foreign.R == nil {
+					foreign.R = &gameParticipantR{}
+				}
+				foreign.R.Game = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadGameTurns allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (gameL) LoadGameTurns(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGame interface{}, mods queries.Applicator) error {
+	var slice []*Game
+	var object *Game
+
+	if singular {
+		object = maybeGame.(*Game)
+	} else {
+		slice = *maybeGame.(*[]*Game)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &gameR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &gameR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`game_turns`), qm.WhereIn(`game_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load game_turns")
+	}
+
+	var resultSlice []*GameTurn
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice game_turns")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on game_turns")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for game_turns")
+	}
+
+	if singular {
+		object.R.GameTurns = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &gameTurnR{}
+			}
+			foreign.R.Game = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.GameID {
+				local.R.GameTurns = append(local.R.GameTurns, foreign)
+				if foreign.R == nil {
+					foreign.R = &gameTurnR{}
+				}
+				foreign.R.Game = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetCreator of the game to the related item.
 // Sets o.R.Creator to related.
 // Adds o to related.R.CreatorGames.
@@ -727,6 +1074,112 @@ func (o *Game) AddGameOperations(ctx context.Context, exec boil.ContextExecutor,
 	return nil
 }
 
+// AddGameParticipants adds the given related objects to the existing relationships
+// of the game, optionally inserting them as new records.
+// Appends related to o.R.GameParticipants.
+// Sets related.R.Game appropriately.
+func (o *Game) AddGameParticipants(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*GameParticipant) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.GameID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"game_participants\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"game_id"}),
+				strmangle.WhereClause("\"", "\"", 2, gameParticipantPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.GameID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &gameR{
+			GameParticipants: related,
+		}
+	} else {
+		o.R.GameParticipants = append(o.R.GameParticipants, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &gameParticipantR{
+				Game: o,
+			}
+		} else {
+			rel.R.Game = o
+		}
+	}
+	return nil
+}
+
+// AddGameTurns adds the given related objects to the existing relationships
+// of the game, optionally inserting them as new records.
+// Appends related to o.R.GameTurns.
+// Sets related.R.Game appropriately.
+func (o *Game) AddGameTurns(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*GameTurn) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.GameID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"game_turns\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"game_id"}),
+				strmangle.WhereClause("\"", "\"", 2, gameTurnPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.GameID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &gameR{
+			GameTurns: related,
+		}
+	} else {
+		o.R.GameTurns = append(o.R.GameTurns, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &gameTurnR{
+				Game: o,
+			}
+		} else {
+			rel.R.Game = o
+		}
+	}
+	return nil
+}
+
 // Games retrieves all the records using an executor.
 func Games(mods ...qm.QueryMod) gameQuery {
 	mods = append(mods, qm.From("\"games\""))
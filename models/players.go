@@ -65,17 +65,23 @@ var PlayerWhere = struct {
 
 // PlayerRels is where relationship names are stored.
 var PlayerRels = struct {
-	GameOperations string
-	CreatorGames   string
+	GameOperations   string
+	GameParticipants string
+	GameTurns        string
+	CreatorGames     string
 }{
-	GameOperations: "GameOperations",
-	CreatorGames:   "CreatorGames",
+	GameOperations:   "GameOperations",
+	GameParticipants: "GameParticipants",
+	GameTurns:        "GameTurns",
+	CreatorGames:     "CreatorGames",
 }
 
 // playerR is where relationships are stored.
 type playerR struct {
-	GameOperations GameOperationSlice
-	CreatorGames   GameSlice
+	GameOperations   GameOperationSlice
+	GameParticipants GameParticipantSlice
+	GameTurns        GameTurnSlice
+	CreatorGames     GameSlice
 }
 
 // NewStruct creates a new relationship struct
@@ -205,6 +211,48 @@ func (o *Player) GameOperations(mods ...qm.QueryMod) gameOperationQuery {
 	return query
 }
 
+// GameParticipants retrieves all the game_participant's GameParticipants with an executor.
+func (o *Player) GameParticipants(mods ...qm.QueryMod) gameParticipantQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"game_participants\".\"player_id\"=?", o.ID),
+	)
+
+	query := GameParticipants(queryMods...)
+	queries.SetFrom(query.Query, "\"game_participants\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"game_participants\".*"})
+	}
+
+	return query
+}
+
+// GameTurns retrieves all the game_turn's GameTurns with an executor.
+func (o *Player) GameTurns(mods ...qm.QueryMod) gameTurnQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"game_turns\".\"player_id\"=?", o.ID),
+	)
+
+	query := GameTurns(queryMods...)
+	queries.SetFrom(query.Query, "\"game_turns\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"game_turns\".*"})
+	}
+
+	return query
+}
+
 // CreatorGames retrieves all the game's Games with an executor via creator_id column.
 func (o *Player) CreatorGames(mods ...qm.QueryMod) gameQuery {
 	var queryMods []qm.QueryMod
@@ -314,6 +362,182 @@ func (playerL) LoadGameOperations(ctx context.Context, e boil.ContextExecutor, s
 	return nil
 }
 
+// LoadGameParticipants allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (playerL) LoadGameParticipants(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlayer interface{}, mods queries.Applicator) error {
+	var slice []*Player
+	var object *Player
+
+	if singular {
+		object = maybePlayer.(*Player)
+	} else {
+		slice = *maybePlayer.(*[]*Player)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &playerR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &playerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`game_participants`), qm.WhereIn(`player_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load game_participants")
+	}
+
+	var resultSlice []*GameParticipant
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice game_participants")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on game_participants")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for game_participants")
+	}
+
+	if singular {
+		object.R.GameParticipants = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &gameParticipantR{}
+			}
+			foreign.R.Player = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.PlayerID {
+				local.R.GameParticipants = append(local.R.GameParticipants, foreign)
+				if foreign.R == nil {
+					foreign.R = &gameParticipantR{}
+				}
+				foreign.R.Player = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadGameTurns allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (playerL) LoadGameTurns(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlayer interface{}, mods queries.Applicator) error {
+	var slice []*Player
+	var object *Player
+
+	if singular {
+		object = maybePlayer.(*Player)
+	} else {
+		slice = *maybePlayer.(*[]*Player)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &playerR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &playerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`game_turns`), qm.WhereIn(`player_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load game_turns")
+	}
+
+	var resultSlice []*GameTurn
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice game_turns")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on game_turns")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for game_turns")
+	}
+
+	if singular {
+		object.R.GameTurns = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &gameTurnR{}
+			}
+			foreign.R.Player = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.PlayerID {
+				local.R.GameTurns = append(local.R.GameTurns, foreign)
+				if foreign.R == nil {
+					foreign.R = &gameTurnR{}
+				}
+				foreign.R.Player = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadCreatorGames allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (playerL) LoadCreatorGames(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlayer interface{}, mods queries.Applicator) error {
@@ -455,6 +679,112 @@ func (o *Player) AddGameOperations(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
+// AddGameParticipants adds the given related objects to the existing relationships
+// of the player, optionally inserting them as new records.
+// Appends related to o.R.GameParticipants.
+// Sets related.R.Player appropriately.
+func (o *Player) AddGameParticipants(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*GameParticipant) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PlayerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"game_participants\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"player_id"}),
+				strmangle.WhereClause("\"", "\"", 2, gameParticipantPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PlayerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &playerR{
+			GameParticipants: related,
+		}
+	} else {
+		o.R.GameParticipants = append(o.R.GameParticipants, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &gameParticipantR{
+				Player: o,
+			}
+		} else {
+			rel.R.Player = o
+		}
+	}
+	return nil
+}
+
+// AddGameTurns adds the given related objects to the existing relationships
+// of the player, optionally inserting them as new records.
+// Appends related to o.R.GameTurns.
+// Sets related.R.Player appropriately.
+func (o *Player) AddGameTurns(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*GameTurn) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PlayerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"game_turns\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"player_id"}),
+				strmangle.WhereClause("\"", "\"", 2, gameTurnPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PlayerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &playerR{
+			GameTurns: related,
+		}
+	} else {
+		o.R.GameTurns = append(o.R.GameTurns, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &gameTurnR{
+				Player: o,
+			}
+		} else {
+			rel.R.Player = o
+		}
+	}
+	return nil
+}
+
 // AddCreatorGames adds the given related objects to the existing relationships
 // of the player, optionally inserting them as new records.
 // Appends related to o.R.CreatorGames.
@@ -1,6 +1,8 @@
 -- CREATE DATABASE minesweeper WITH OWNER 'minesweeper' ENCODING 'UTF8';
 
-CREATE TYPE mine_operation AS ENUM ('reveal', 'mark');
+CREATE TYPE mine_operation AS ENUM ('reveal', 'mark', 'chord', 'hint', 'undo');
+CREATE TYPE board_topology AS ENUM ('square', 'hex');
+CREATE TYPE participant_role AS ENUM ('owner', 'player', 'spectator');
 
 
 CREATE TABLE players(
@@ -13,6 +15,26 @@ CREATE TABLE players(
 
 CREATE UNIQUE INDEX idx_players_name ON players (name);
 
+-- named board configurations, administrators define new presets inserting them in this table
+CREATE TABLE game_presets(
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    name TEXT NOT NULL,
+    rows SMALLINT NOT NULL,
+    cols SMALLINT NOT NULL,
+    mines SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT cnst_game_presets_board CHECK (cols > 0 AND rows > 0 AND cols <= 100 AND rows <= 100),
+    CONSTRAINT cnst_game_presets_mines CHECK (mines > 0 AND (rows * cols) - 1 > mines)
+);
+
+CREATE UNIQUE INDEX idx_game_presets_name ON game_presets (name);
+
+INSERT INTO game_presets (name, rows, cols, mines, created_at) VALUES
+    ('beginner', 9, 9, 10, NOW()),
+    ('intermediate', 16, 16, 40, NOW()),
+    ('expert', 16, 30, 99, NOW());
+
 CREATE TABLE games(
     id BIGSERIAL NOT NULL PRIMARY KEY,
     private BOOLEAN NOT NULL DEFAULT FALSE,
@@ -25,8 +47,42 @@ CREATE TABLE games(
     creator_id BIGINT NOT NULL,
     created_at TIMESTAMPTZ,
     updated_at TIMESTAMPTZ,
-    CONSTRAINT cnst_games_board CHECK (cols > 0 AND rows > 0 AND cols <= 100 AND rows <= 100),
-    CONSTRAINT cnst_games_mines CHECK (mines > 0 AND (rows * cols) - 1 > mines),
+    -- true until the first reveal has moved the mines away from the revealed point
+    safe_first_reveal BOOLEAN NOT NULL DEFAULT TRUE,
+    -- the seed and the generator version reproduce the board, 0 is used for boards that were not generated
+    seed BIGINT NOT NULL,
+    generator_version SMALLINT NOT NULL,
+    no_guess BOOLEAN NOT NULL DEFAULT FALSE,
+    -- how the points of the board are connected, square points have 8 neighbours and hex points have 6
+    topology board_topology NOT NULL DEFAULT 'square',
+    -- true when the last row and column of the board are next to the first row and column
+    wrap BOOLEAN NOT NULL DEFAULT FALSE,
+    -- the depth of the board, every layer has rows * cols points
+    layers SMALLINT NOT NULL DEFAULT 1,
+    -- the name of the preset the game was created from, if any
+    preset TEXT,
+    -- the clock of the game stops while it is paused, paused_millis accumulates the time of every finished pause
+    paused_at TIMESTAMPTZ,
+    paused_millis BIGINT NOT NULL DEFAULT 0,
+    -- casual games let the players undo their last operations, undos counts every undone operation
+    casual BOOLEAN NOT NULL DEFAULT FALSE,
+    undos INTEGER NOT NULL DEFAULT 0,
+    -- true when the creator gave up the game instead of winning or hitting a mine
+    resigned BOOLEAN NOT NULL DEFAULT FALSE,
+    -- archived games are not listed but they are kept for stats and replays
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    -- only the creator can play a spectate only game, the other players can only watch it
+    spectate_only BOOLEAN NOT NULL DEFAULT FALSE,
+    -- every player of a competitive game scores their own points and hitting a mine only eliminates the player
+    competitive BOOLEAN NOT NULL DEFAULT FALSE,
+    -- the players of a turn based game play one at a time, in the order of the game_turns, and a turn is passed
+    -- when its turn_seconds run out. A game without a time limit has 0 turn_seconds
+    turn_based BOOLEAN NOT NULL DEFAULT FALSE,
+    turn_seconds INTEGER NOT NULL DEFAULT 0,
+    turn_position INTEGER NOT NULL DEFAULT 0,
+    turn_started_at TIMESTAMPTZ,
+    CONSTRAINT cnst_games_board CHECK (cols > 0 AND rows > 0 AND layers > 0 AND cols <= 100 AND rows <= 100 AND layers <= 10),
+    CONSTRAINT cnst_games_mines CHECK (mines > 0 AND (layers * rows * cols) - 1 > mines),
     CONSTRAINT fk_games_creator FOREIGN KEY (creator_id) REFERENCES players (id)
 );
 
@@ -36,16 +92,48 @@ CREATE TABLE game_operations(
     player_id BIGINT NOT NULL,
     row SMALLINT NOT NULL,
     col SMALLINT NOT NULL,
+    layer SMALLINT NOT NULL DEFAULT 0,
     operation_id INTEGER NOT NULL,
     mine_proximity SMALLINT NOT NULL,
     operation mine_operation NOT NULL,
-    CONSTRAINT fk_game_operation_game FOREIGN KEY (game_id) REFERENCES games (id),
+    created_at TIMESTAMPTZ,
+    -- the points that the operation scored for its player in a competitive game, hitting a mine is negative
+    score INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT fk_game_operation_game FOREIGN KEY (game_id) REFERENCES games (id) ON DELETE CASCADE,
     CONSTRAINT fk_games_creator FOREIGN KEY (player_id) REFERENCES players (id)
 );
 
 CREATE INDEX idx_game_operation_game ON game_operations (game_id);
 CREATE UNIQUE INDEX idx_game_operation ON game_operations (game_id, operation_id);
 
+CREATE TABLE game_turns(
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    game_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    position SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_game_turns_game FOREIGN KEY (game_id) REFERENCES games (id) ON DELETE CASCADE,
+    CONSTRAINT fk_game_turns_player FOREIGN KEY (player_id) REFERENCES players (id)
+);
+
+CREATE UNIQUE INDEX idx_game_turn_player ON game_turns (game_id, player_id);
+CREATE UNIQUE INDEX idx_game_turn_position ON game_turns (game_id, position);
+
+-- the participants of a game and their role in it. The creator is the owner of the game, and a player invited
+-- to a game is a player or a spectator of it once the invitation is accepted
+CREATE TABLE game_participants(
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    game_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    role participant_role NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_game_participants_game FOREIGN KEY (game_id) REFERENCES games (id) ON DELETE CASCADE,
+    CONSTRAINT fk_game_participants_player FOREIGN KEY (player_id) REFERENCES players (id)
+);
+
+CREATE UNIQUE INDEX idx_game_participant ON game_participants (game_id, player_id);
+
 CREATE TABLE game_board_points(
     id BIGSERIAL NOT NULL PRIMARY KEY,
     game_id BIGINT NOT NULL,
@@ -54,9 +142,10 @@ CREATE TABLE game_board_points(
     mine_proximity SMALLINT NOT NULL,
     created_at TIMESTAMPTZ,
     updated_at TIMESTAMPTZ,
-    CONSTRAINT cnst_games_map_x_y CHECK (row >= 0 AND col >= 0 AND row < 100 AND col < 100),
-    CONSTRAINT fk_games_map_game FOREIGN KEY (game_id) REFERENCES games (id)
+    layer SMALLINT NOT NULL DEFAULT 0,
+    CONSTRAINT cnst_games_map_x_y CHECK (row >= 0 AND col >= 0 AND layer >= 0 AND row < 100 AND col < 100 AND layer < 10),
+    CONSTRAINT fk_games_map_game FOREIGN KEY (game_id) REFERENCES games (id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_game_board ON game_board_points (game_id, row, col);
+CREATE UNIQUE INDEX idx_game_board ON game_board_points (game_id, layer, row, col);
 CREATE INDEX idx_game_board_mine_proximity ON game_board_points (game_id, mine_proximity);
\ No newline at end of file
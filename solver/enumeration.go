@@ -0,0 +1,305 @@
+package solver
+
+// group is a set of unknown points connected by the constraints around them. The mines of a group do not depend
+// on the mines of the other groups besides the total amount of mines.
+type group struct {
+	// cells are the points of the group in search order
+	cells           []int
+	constraints     []groupConstraint
+	cellConstraints [][]int
+	steps           int
+	// solutions counts the mine combinations by amount of mines and mineCount counts how many of those
+	// combinations have a mine in every point
+	solutions []int
+	mineCount [][]int
+}
+
+// groupConstraint tracks a constraint while searching the mine combinations
+type groupConstraint struct {
+	need     int
+	assigned int
+	open     int
+}
+
+// newGroups splits the unknown points of the constraints in groups
+func newGroups(constraints []frontierConstraint) []*group {
+	byCell := make(map[int][]int)
+	for ci, c := range constraints {
+		for _, u := range c.unknowns {
+			byCell[u] = append(byCell[u], ci)
+		}
+	}
+	visited := make([]bool, len(constraints))
+	seen := make(map[int]bool)
+	groups := make([]*group, 0)
+	for start := range constraints {
+		if visited[start] {
+			continue
+		}
+		g := &group{}
+		local := make(map[int]int)
+		visited[start] = true
+		queue := []int{start}
+		for len(queue) > 0 {
+			ci := queue[0]
+			queue = queue[1:]
+			local[ci] = len(g.constraints)
+			g.constraints = append(g.constraints, groupConstraint{
+				need: constraints[ci].need,
+				open: len(constraints[ci].unknowns),
+			})
+			for _, u := range constraints[ci].unknowns {
+				if !seen[u] {
+					seen[u] = true
+					g.cells = append(g.cells, u)
+				}
+				for _, other := range byCell[u] {
+					if !visited[other] {
+						visited[other] = true
+						queue = append(queue, other)
+					}
+				}
+			}
+		}
+		g.cellConstraints = make([][]int, len(g.cells))
+		for li, u := range g.cells {
+			for _, ci := range byCell[u] {
+				g.cellConstraints[li] = append(g.cellConstraints[li], local[ci])
+			}
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// search counts the mine combinations of the group, it returns false if it takes more than maxEnumerationSteps
+func (g *group) search(i, mines int, assignment []bool) bool {
+	g.steps++
+	if g.steps > maxEnumerationSteps {
+		return false
+	}
+	if i == len(g.cells) {
+		for len(g.solutions) <= mines {
+			g.solutions = append(g.solutions, 0)
+			g.mineCount = append(g.mineCount, nil)
+		}
+		g.solutions[mines]++
+		if g.mineCount[mines] == nil {
+			g.mineCount[mines] = make([]int, len(g.cells))
+		}
+		for li, m := range assignment {
+			if m {
+				g.mineCount[mines][li]++
+			}
+		}
+		return true
+	}
+	for v := 0; v <= 1; v++ {
+		ok := true
+		for _, ci := range g.cellConstraints[i] {
+			c := &g.constraints[ci]
+			c.assigned += v
+			c.open--
+			if c.assigned > c.need || c.assigned+c.open < c.need {
+				ok = false
+			}
+		}
+		assignment[i] = v == 1
+		completed := !ok || g.search(i+1, mines+v, assignment)
+		for _, ci := range g.cellConstraints[i] {
+			g.constraints[ci].assigned -= v
+			g.constraints[ci].open++
+		}
+		if !completed {
+			return false
+		}
+	}
+	return true
+}
+
+// enumerate searches every mine combination of the groups of unknown points next to revealed points. The points
+// that have a mine in every combination are mines and the points that never have a mine are safe.
+func (s *Solver) enumerate(constraints []frontierConstraint) (bool, error) {
+	if len(constraints) == 0 {
+		return false, nil
+	}
+	groups := newGroups(constraints)
+	feasible := make([][]bool, len(groups))
+	inGroup := make([]bool, len(s.cells))
+	complete := true
+	for k, g := range groups {
+		for _, cell := range g.cells {
+			inGroup[cell] = true
+		}
+		if !g.search(0, 0, make([]bool, len(g.cells))) {
+			complete = false
+			continue
+		}
+		feasible[k] = make([]bool, len(g.solutions))
+		for m, count := range g.solutions {
+			feasible[k][m] = count > 0
+		}
+		if len(feasible[k]) == 0 {
+			return false, ErrInconsistentBoard
+		}
+	}
+	changed := false
+	if s.mines > 0 && complete {
+		var err error
+		changed, err = s.applyGroupMineCount(feasible, inGroup)
+		if err != nil {
+			return changed, err
+		}
+	}
+	for k, g := range groups {
+		if feasible[k] == nil {
+			continue
+		}
+		for li, cell := range g.cells {
+			always, never := true, true
+			for m, ok := range feasible[k] {
+				if !ok {
+					continue
+				}
+				if g.mineCount[m][li] != g.solutions[m] {
+					always = false
+				}
+				if g.mineCount[m][li] != 0 {
+					never = false
+				}
+			}
+			if s.cells[cell] != unknown {
+				continue
+			}
+			if never {
+				s.set(cell, safe)
+				changed = true
+			} else if always {
+				s.set(cell, mine)
+				changed = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// applyGroupMineCount discards the amounts of mines of every group that do not add up to the total amount of
+// mines and deduces the points that are not next to any revealed point
+func (s *Solver) applyGroupMineCount(feasible [][]bool, inGroup []bool) (bool, error) {
+	mines, unknowns := s.remaining()
+	interior := 0
+	for i, v := range s.cells {
+		if v == unknown && !inGroup[i] {
+			interior++
+		}
+	}
+	n := len(feasible)
+	// prefix[k] are the amounts of mines the groups before k can hold, suffix[k] the ones from k onwards
+	prefix := make([][]bool, n+1)
+	suffix := make([][]bool, n+1)
+	prefix[0] = []bool{true}
+	suffix[n] = []bool{true}
+	for k := 0; k < n; k++ {
+		prefix[k+1] = combine(prefix[k], feasible[k])
+		suffix[n-k-1] = combine(feasible[n-k-1], suffix[n-k])
+	}
+	for k := range feasible {
+		others := cumulative(suffix[k+1])
+		for m, ok := range feasible[k] {
+			if ok {
+				// the other groups leave between zero and interior mines for the interior points
+				feasible[k][m] = reachable(prefix[k], others, mines-m-interior, mines-m)
+			}
+		}
+		if !contains(feasible[k]) {
+			return false, ErrInconsistentBoard
+		}
+	}
+	if interior == 0 || unknowns == 0 {
+		return false, nil
+	}
+	onlyEmpty, onlyMines, found := true, true, false
+	for m, ok := range prefix[n] {
+		left := mines - m
+		if !ok || left < 0 || left > interior {
+			continue
+		}
+		found = true
+		onlyEmpty = onlyEmpty && left == 0
+		onlyMines = onlyMines && left == interior
+	}
+	if !found {
+		return false, ErrInconsistentBoard
+	}
+	if !onlyEmpty && !onlyMines {
+		return false, nil
+	}
+	state := safe
+	if onlyMines {
+		state = mine
+	}
+	for i, v := range s.cells {
+		if v == unknown && !inGroup[i] {
+			s.set(i, state)
+		}
+	}
+	return true, nil
+}
+
+// combine returns the amounts of mines that two sets of groups can hold together
+func combine(a, b []bool) []bool {
+	sums := make([]bool, len(a)+len(b)-1)
+	for x, okA := range a {
+		if !okA {
+			continue
+		}
+		for y, okB := range b {
+			if okB {
+				sums[x+y] = true
+			}
+		}
+	}
+	return sums
+}
+
+// cumulative returns the amount of true values before every index of b
+func cumulative(b []bool) []int {
+	count := make([]int, len(b)+1)
+	for y, ok := range b {
+		count[y+1] = count[y]
+		if ok {
+			count[y+1]++
+		}
+	}
+	return count
+}
+
+// reachable returns true if an amount of a plus an amount of b is between lo and hi, count is the cumulative
+// of b
+func reachable(a []bool, count []int, lo, hi int) bool {
+	for x, ok := range a {
+		if !ok {
+			continue
+		}
+		from, to := lo-x, hi-x
+		if from < 0 {
+			from = 0
+		}
+		if to > len(count)-2 {
+			to = len(count) - 2
+		}
+		if from <= to && count[to+1]-count[from] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []bool) bool {
+	for _, v := range values {
+		if v {
+			return true
+		}
+	}
+	return false
+}
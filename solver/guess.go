@@ -0,0 +1,70 @@
+package solver
+
+import "errors"
+
+// ErrNothingToGuess is returned when every point of the board is known
+var ErrNothingToGuess = errors.New("nothing to guess")
+
+// Guess returns the unknown point with the lowest estimated probability of having a mine and that probability.
+// The points next to revealed points are estimated counting the mine combinations of their group, the rest of
+// the points share the mines left, or are estimated as mines when the total amount of mines is not known.
+func (s *Solver) Guess() (Point, float64, error) {
+	err := s.solve(nil)
+	if err != nil {
+		return Point{}, 0, err
+	}
+	constraints, _, err := s.frontier()
+	if err != nil {
+		return Point{}, 0, err
+	}
+	risk := make(map[int]float64)
+	expected := 0.0
+	for _, g := range newGroups(constraints) {
+		if !g.search(0, 0, make([]bool, len(g.cells))) {
+			continue
+		}
+		total := 0
+		for _, count := range g.solutions {
+			total += count
+		}
+		for li, cell := range g.cells {
+			mines := 0
+			for m := range g.solutions {
+				if g.mineCount[m] != nil {
+					mines += g.mineCount[m][li]
+				}
+			}
+			risk[cell] = float64(mines) / float64(total)
+			expected += risk[cell]
+		}
+	}
+	density := 1.0
+	mines, unknowns := s.remaining()
+	if s.mines > 0 && unknowns > len(risk) {
+		density = (float64(mines) - expected) / float64(unknowns-len(risk))
+		if density < 0 {
+			density = 0
+		} else if density > 1 {
+			density = 1
+		}
+	}
+	best := -1
+	bestRisk := 0.0
+	for i, v := range s.cells {
+		if v != unknown {
+			continue
+		}
+		r, ok := risk[i]
+		if !ok {
+			r = density
+		}
+		if best < 0 || r < bestRisk {
+			best = i
+			bestRisk = r
+		}
+	}
+	if best < 0 {
+		return Point{}, 0, ErrNothingToGuess
+	}
+	return s.point(best), bestRisk, nil
+}
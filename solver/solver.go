@@ -0,0 +1,444 @@
+package solver
+
+import (
+	"errors"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/topology"
+	"github.com/volatiletech/null"
+)
+
+// maxEnumerationSteps bounds the search of the mine combinations of a group of unknown points, the groups that
+// need more steps are left without deductions
+const maxEnumerationSteps = 1 << 16
+
+const (
+	// unknown is a point that has not been revealed and nothing is known about it
+	unknown = -1 - iota
+	// safe is a point that has not been revealed and is known to have no mine
+	safe
+	// mine is a point that has not been revealed and is known to have a mine
+	mine
+	// flagged is a point that the player marked as a mine or a revealed mine
+	flagged
+)
+
+var (
+	// ErrInvalidBoard is returned when the board is empty, it is not rectangular or it has values out of bounds
+	ErrInvalidBoard = errors.New("invalid board")
+	// ErrInconsistentBoard is returned when there is no way of placing the mines that matches the board
+	ErrInconsistentBoard = errors.New("inconsistent board")
+)
+
+// Point is a point in the board
+type Point struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Solution contains the unrevealed points that are certainly safe and the unflagged points that are certainly mines
+type Solution struct {
+	Safe  []Point `json:"safe"`
+	Mines []Point `json:"mines"`
+}
+
+// Solver deduces the safe points and the mines of a board as seen by a player. The deductions are kept, so
+// points can be revealed after solving to keep on solving the same board.
+type Solver struct {
+	rows       int
+	cols       int
+	mines      int
+	neighbours topology.Neighbours
+	cells      []int
+	pending    []int
+	queued     []bool
+	// found are the points deduced safe that Play has not revealed yet
+	found []int
+	// points is reused to read the neighbours of every point
+	points []topology.Point
+}
+
+// Solve deduces the safe points and the mines of a board as seen by a player. See New for the arguments.
+func Solve(board [][]null.Int, flags [][]bool, mines int) (Solution, error) {
+	s, err := New(board, flags, mines)
+	if err != nil {
+		return Solution{}, err
+	}
+	return s.Solve()
+}
+
+// New creates a solver for a board as seen by a player. Revealed points hold the amount of sibling mines and
+// revealed mines hold algebra.RevealedMine, unrevealed points are null. Flags are the unrevealed points that the player marked as
+// mines, it can be nil, and the flags are trusted to be mines. Mines is the total amount of mines in the board,
+// or zero if it is not known. The board is a square grid.
+func New(board [][]null.Int, flags [][]bool, mines int) (*Solver, error) {
+	return NewWithTopology(board, flags, mines, topology.Square)
+}
+
+// NewWithTopology creates a solver for a board as seen by a player whose points are connected by the given
+// neighbours function, or a square grid if it is nil. See New for the rest of the arguments.
+func NewWithTopology(board [][]null.Int, flags [][]bool, mines int, neighbours topology.Neighbours) (*Solver, error) {
+	if neighbours == nil {
+		neighbours = topology.Square
+	}
+	rows := len(board)
+	if rows == 0 || len(board[0]) == 0 || (flags != nil && len(flags) != rows) || mines < 0 {
+		return nil, ErrInvalidBoard
+	}
+	cols := len(board[0])
+	s := &Solver{
+		rows:       rows,
+		cols:       cols,
+		mines:      mines,
+		neighbours: neighbours,
+		cells:      make([]int, rows*cols),
+		queued:     make([]bool, rows*cols),
+	}
+	for r := range board {
+		if len(board[r]) != cols || (flags != nil && len(flags[r]) != cols) {
+			return nil, ErrInvalidBoard
+		}
+		for c, v := range board[r] {
+			i := r*cols + c
+			if !v.Valid {
+				s.cells[i] = unknown
+				if flags != nil && flags[r][c] {
+					s.cells[i] = flagged
+				}
+			} else if v.Int >= 0 && v.Int <= algebra.MaxMineProximity {
+				s.cells[i] = v.Int
+				s.push(i)
+			} else if v.Int == algebra.RevealedMine {
+				s.cells[i] = flagged
+			} else {
+				return nil, ErrInvalidBoard
+			}
+		}
+	}
+	return s, nil
+}
+
+// Reveal adds a revealed point with the amount of sibling mines to the board
+func (s *Solver) Reveal(row, col, proximity int) error {
+	if row < 0 || row >= s.rows || col < 0 || col >= s.cols || proximity < 0 || proximity > algebra.MaxMineProximity {
+		return ErrInvalidBoard
+	}
+	i := row*s.cols + col
+	if s.cells[i] >= 0 {
+		if s.cells[i] != proximity {
+			return ErrInconsistentBoard
+		}
+		return nil
+	}
+	if s.isMine(i) {
+		return ErrInconsistentBoard
+	}
+	s.set(i, proximity)
+	return nil
+}
+
+// Solve deduces every safe point and mine that can be deduced from the board
+func (s *Solver) Solve() (Solution, error) {
+	err := s.solve(nil)
+	if err != nil {
+		return Solution{}, err
+	}
+	return s.solution(), nil
+}
+
+// Play reveals the safe points as soon as they are deduced, reveal returns the amount of sibling mines of a
+// point. It stops when nothing else can be deduced and returns the mines found.
+func (s *Solver) Play(reveal func(row, col int) int) (Solution, error) {
+	err := s.solve(reveal)
+	if err != nil {
+		return Solution{}, err
+	}
+	return s.solution(), nil
+}
+
+// solve applies the rules from the cheapest to the most expensive, going back to the cheapest after every change
+func (s *Solver) solve(reveal func(row, col int) int) error {
+	for {
+		err := s.propagate()
+		if err != nil {
+			return err
+		}
+		if reveal != nil && len(s.found) > 0 {
+			found := s.found
+			s.found = nil
+			for _, i := range found {
+				p := s.point(i)
+				err = s.Reveal(p.Row, p.Col, reveal(p.Row, p.Col))
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		constraints, index, err := s.frontier()
+		if err != nil {
+			return err
+		}
+		changed := s.applySubsetRule(constraints, index)
+		if !changed {
+			changed, err = s.applyMineCountRule()
+		}
+		if err == nil && !changed {
+			changed, err = s.enumerate(constraints)
+		}
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+	}
+}
+
+// solution returns the safe points and the mines deduced
+func (s *Solver) solution() Solution {
+	solution := Solution{
+		Safe:  make([]Point, 0),
+		Mines: make([]Point, 0),
+	}
+	for i, v := range s.cells {
+		if v == safe {
+			solution.Safe = append(solution.Safe, s.point(i))
+		} else if v == mine {
+			solution.Mines = append(solution.Mines, s.point(i))
+		}
+	}
+	return solution
+}
+
+func (s *Solver) point(i int) Point {
+	return Point{Row: i / s.cols, Col: i % s.cols}
+}
+
+func (s *Solver) isMine(i int) bool {
+	return s.cells[i] == mine || s.cells[i] == flagged
+}
+
+// siblings appends the siblings of a point to buf
+func (s *Solver) siblings(i int, buf []int) []int {
+	s.points = s.neighbours(s.rows, s.cols, i/s.cols, i%s.cols, s.points[:0])
+	for _, p := range s.points {
+		buf = append(buf, p.Row*s.cols+p.Col)
+	}
+	return buf
+}
+
+// push queues a revealed point to check its siblings
+func (s *Solver) push(i int) {
+	if !s.queued[i] && s.cells[i] >= 0 {
+		s.queued[i] = true
+		s.pending = append(s.pending, i)
+	}
+}
+
+// set changes the state of a point and queues the revealed points around it
+func (s *Solver) set(i, v int) {
+	var buf [8]int
+	if v == safe {
+		s.found = append(s.found, i)
+	}
+	s.cells[i] = v
+	s.push(i)
+	for _, sibling := range s.siblings(i, buf[:0]) {
+		s.push(sibling)
+	}
+}
+
+// constraint appends the unknown siblings of a revealed point to buf and returns the amount of mines among them
+func (s *Solver) constraint(i int, buf []int) (int, []int, error) {
+	var siblings [8]int
+	need := s.cells[i]
+	for _, sibling := range s.siblings(i, siblings[:0]) {
+		if s.isMine(sibling) {
+			need--
+		} else if s.cells[sibling] == unknown {
+			buf = append(buf, sibling)
+		}
+	}
+	if need < 0 || need > len(buf) {
+		return need, buf, ErrInconsistentBoard
+	}
+	return need, buf, nil
+}
+
+// propagate applies the single point rule to the queued points: when a revealed point has no more mines around
+// its unknown siblings are safe, and when it has as many unknown siblings as mines left they are all mines
+func (s *Solver) propagate() error {
+	var buf [8]int
+	for len(s.pending) > 0 {
+		i := s.pending[len(s.pending)-1]
+		s.pending = s.pending[:len(s.pending)-1]
+		s.queued[i] = false
+		need, unknowns, err := s.constraint(i, buf[:0])
+		if err != nil {
+			return err
+		}
+		if len(unknowns) == 0 {
+			continue
+		}
+		v := 0
+		if need == 0 {
+			v = safe
+		} else if need == len(unknowns) {
+			v = mine
+		}
+		if v != 0 {
+			for _, u := range unknowns {
+				s.set(u, v)
+			}
+		}
+	}
+	return nil
+}
+
+// frontierConstraint is a revealed point with unknown siblings and the amount of mines among them
+type frontierConstraint struct {
+	unknowns []int
+	need     int
+}
+
+// frontier returns the constraints of the revealed points that have unknown siblings and the index of the
+// constraint of every point, -1 for the points without a constraint
+func (s *Solver) frontier() ([]frontierConstraint, []int, error) {
+	var buf [8]int
+	constraints := make([]frontierConstraint, 0)
+	index := make([]int, len(s.cells))
+	// the unknown points of all the constraints share the same backing arrays
+	store := make([]int, 0, 1024)
+	for i := range s.cells {
+		index[i] = -1
+		if s.cells[i] <= 0 {
+			continue
+		}
+		need, unknowns, err := s.constraint(i, buf[:0])
+		if err != nil {
+			return constraints, index, err
+		}
+		if len(unknowns) > 0 {
+			if cap(store)-len(store) < len(unknowns) {
+				store = make([]int, 0, 1024)
+			}
+			start := len(store)
+			store = append(store, unknowns...)
+			index[i] = len(constraints)
+			constraints = append(constraints, frontierConstraint{unknowns: store[start:len(store):len(store)], need: need})
+		}
+	}
+	return constraints, index, nil
+}
+
+// applySubsetRule compares constraints whose unknown points contain the unknown points of another constraint,
+// the points that are not shared hold the difference of mines
+func (s *Solver) applySubsetRule(constraints []frontierConstraint, index []int) bool {
+	changed := false
+	var siblings, farSiblings [8]int
+	for i, v := range index {
+		if v < 0 {
+			continue
+		}
+		// only constraints up to two points away can share unknown points
+		for _, sibling := range s.siblings(i, siblings[:0]) {
+			for _, o := range s.siblings(sibling, farSiblings[:0]) {
+				if index[o] < 0 || o == i {
+					continue
+				}
+				outer := constraints[index[o]]
+				diff, ok := difference(outer.unknowns, constraints[v].unknowns)
+				if !ok || len(diff) == 0 {
+					continue
+				}
+				mines := outer.need - constraints[v].need
+				state := 0
+				if mines == 0 {
+					state = safe
+				} else if mines == len(diff) {
+					state = mine
+				}
+				if state == 0 {
+					continue
+				}
+				for _, d := range diff {
+					if s.cells[d] == unknown {
+						s.set(d, state)
+						changed = true
+					}
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// difference returns the points of set that are not in subset, it returns false if subset is not contained in set
+func difference(set, subset []int) ([]int, bool) {
+	for _, p := range subset {
+		found := false
+		for _, q := range set {
+			if p == q {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	diff := make([]int, 0, len(set)-len(subset))
+	for _, p := range set {
+		found := false
+		for _, q := range subset {
+			if p == q {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, p)
+		}
+	}
+	return diff, true
+}
+
+// remaining returns the amount of mines that are not known and the amount of unknown points
+func (s *Solver) remaining() (int, int) {
+	mines := s.mines
+	unknowns := 0
+	for i, v := range s.cells {
+		if s.isMine(i) {
+			mines--
+		} else if v == unknown {
+			unknowns++
+		}
+	}
+	return mines, unknowns
+}
+
+// applyMineCountRule uses the total amount of mines: when every mine is known the unknown points are safe, and
+// when there are as many unknown points as mines left they are all mines
+func (s *Solver) applyMineCountRule() (bool, error) {
+	if s.mines == 0 {
+		return false, nil
+	}
+	mines, unknowns := s.remaining()
+	if mines < 0 || mines > unknowns {
+		return false, ErrInconsistentBoard
+	}
+	if unknowns == 0 || (mines != 0 && mines != unknowns) {
+		return false, nil
+	}
+	state := safe
+	if mines != 0 {
+		state = mine
+	}
+	for i, v := range s.cells {
+		if v == unknown {
+			s.set(i, state)
+		}
+	}
+	return true, nil
+}
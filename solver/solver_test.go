@@ -0,0 +1,355 @@
+package solver
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/javiercbk/minesweeper/algebra"
+	"github.com/javiercbk/minesweeper/topology"
+	"github.com/volatiletech/null"
+)
+
+type solverTest struct {
+	board    []string
+	mines    int
+	expected Solution
+	err      error
+}
+
+// parseBoard reads a board where digits are revealed points, '*' is a revealed mine, 'F' is a flagged point and
+// any other character is an unrevealed point
+func parseBoard(rows []string) ([][]null.Int, [][]bool) {
+	board := make([][]null.Int, len(rows))
+	flags := make([][]bool, len(rows))
+	for r, row := range rows {
+		board[r] = make([]null.Int, len(row))
+		flags[r] = make([]bool, len(row))
+		for c, ch := range row {
+			if ch >= '0' && ch <= '8' {
+				board[r][c] = null.IntFrom(int(ch - '0'))
+			} else if ch == '*' {
+				board[r][c] = null.IntFrom(algebra.RevealedMine)
+			} else if ch == 'F' {
+				flags[r][c] = true
+			}
+		}
+	}
+	return board, flags
+}
+
+func TestSolve(t *testing.T) {
+	testTable := []solverTest{
+		{
+			board: []string{"1?"},
+			expected: Solution{
+				Safe:  []Point{},
+				Mines: []Point{{Row: 0, Col: 1}},
+			},
+		},
+		{
+			board: []string{"0??"},
+			expected: Solution{
+				Safe:  []Point{{Row: 0, Col: 1}},
+				Mines: []Point{},
+			},
+		},
+		{
+			board: []string{"F1?"},
+			expected: Solution{
+				Safe:  []Point{{Row: 0, Col: 2}},
+				Mines: []Point{},
+			},
+		},
+		{
+			board: []string{"*1?"},
+			expected: Solution{
+				Safe:  []Point{{Row: 0, Col: 2}},
+				Mines: []Point{},
+			},
+		},
+		{
+			// 1-2-1 pattern needs the subset rule
+			board: []string{
+				"???",
+				"121",
+			},
+			expected: Solution{
+				Safe:  []Point{{Row: 0, Col: 1}},
+				Mines: []Point{{Row: 0, Col: 0}, {Row: 0, Col: 2}},
+			},
+		},
+		{
+			board: []string{
+				"1??",
+				"???",
+				"???",
+			},
+			expected: Solution{
+				Safe:  []Point{},
+				Mines: []Point{},
+			},
+		},
+		{
+			// the only mine is next to the revealed point so the rest is safe
+			board: []string{
+				"1??",
+				"???",
+				"???",
+			},
+			mines: 1,
+			expected: Solution{
+				Safe:  []Point{{Row: 0, Col: 2}, {Row: 1, Col: 2}, {Row: 2, Col: 0}, {Row: 2, Col: 1}, {Row: 2, Col: 2}},
+				Mines: []Point{},
+			},
+		},
+		{
+			// every unrevealed point is a mine
+			board: []string{
+				"3?",
+				"??",
+			},
+			mines: 3,
+			expected: Solution{
+				Safe:  []Point{},
+				Mines: []Point{{Row: 0, Col: 1}, {Row: 1, Col: 0}, {Row: 1, Col: 1}},
+			},
+		},
+		{
+			board: []string{"2?"},
+			err:   ErrInconsistentBoard,
+		},
+		{
+			board: []string{"1?"},
+			mines: 2,
+			err:   ErrInconsistentBoard,
+		},
+		{
+			board: []string{"1?", "?"},
+			err:   ErrInvalidBoard,
+		},
+		{
+			board: []string{},
+			err:   ErrInvalidBoard,
+		},
+	}
+	for i, test := range testTable {
+		board, flags := parseBoard(test.board)
+		solution, err := Solve(board, flags, test.mines)
+		if err != test.err {
+			t.Fatalf("test %d, failed: expected error %v but got %v\n", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(solution, test.expected) {
+			t.Fatalf("test %d, failed: expected solution %v but got %v\n", i, test.expected, solution)
+		}
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	// a+b = 1, b+c = 1, a+c = 2, no constraint contains another one
+	s := &Solver{
+		rows:       1,
+		cols:       3,
+		neighbours: topology.Square,
+		cells:      []int{unknown, unknown, unknown},
+		queued:     make([]bool, 3),
+	}
+	constraints := []frontierConstraint{
+		{unknowns: []int{0, 1}, need: 1},
+		{unknowns: []int{1, 2}, need: 1},
+		{unknowns: []int{0, 2}, need: 2},
+	}
+	changed, err := s.enumerate(constraints)
+	if err != nil || !changed {
+		t.Fatalf("expected enumeration to deduce points but got %v, %v\n", changed, err)
+	}
+	expected := []int{mine, safe, mine}
+	if !reflect.DeepEqual(s.cells, expected) {
+		t.Fatalf("expected cells %v but got %v\n", expected, s.cells)
+	}
+}
+
+func TestSolveLayers(t *testing.T) {
+	// the center of a cube of three layers of 3x3 points touches the other 26 points
+	board := make([][]null.Int, 9)
+	for r := range board {
+		board[r] = make([]null.Int, 3)
+	}
+	board[4][1] = null.IntFrom(algebra.MaxMineProximity)
+	s, err := NewWithTopology(board, nil, 26, topology.Cube(3))
+	if err != nil {
+		t.Fatalf("error creating solver: %v\n", err)
+	}
+	solution, err := s.Solve()
+	if err != nil {
+		t.Fatalf("error solving: %v\n", err)
+	}
+	if len(solution.Safe) != 0 || len(solution.Mines) != 26 {
+		t.Fatalf("expected the 26 siblings to be mines but got %v\n", solution)
+	}
+	err = s.Reveal(4, 1, algebra.MaxMineProximity+1)
+	if err != ErrInvalidBoard {
+		t.Fatalf("expected err to be %v but was %v\n", ErrInvalidBoard, err)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	// 1-2-1 pattern on top of a row of empty points
+	full := [][]int{
+		{9, 2, 9},
+		{1, 2, 1},
+		{0, 0, 0},
+	}
+	board, _ := parseBoard([]string{"???", "???", "0??"})
+	s, err := New(board, nil, 2)
+	if err != nil {
+		t.Fatalf("error creating solver: %v\n", err)
+	}
+	revealed := 1
+	solution, err := s.Play(func(row, col int) int {
+		revealed++
+		return full[row][col]
+	})
+	if err != nil {
+		t.Fatalf("error playing: %v\n", err)
+	}
+	if revealed != 7 || len(solution.Safe) != 0 || len(solution.Mines) != 2 {
+		t.Fatalf("expected the whole board to be solved but got %d revealed and %v\n", revealed, solution)
+	}
+}
+
+func TestGuess(t *testing.T) {
+	testTable := []struct {
+		board    []string
+		mines    int
+		expected Point
+		risk     float64
+		err      error
+	}{
+		{
+			// the siblings of the one share a mine, the other points share the other mine
+			board: []string{
+				"1??",
+				"???",
+				"???",
+			},
+			mines:    2,
+			expected: Point{Row: 0, Col: 2},
+			risk:     0.2,
+		},
+		{
+			// the one holds a mine shared with the two, the other mine of the two is in one of its five
+			// points that are not next to the one
+			board: []string{
+				"???",
+				"?2?",
+				"1??",
+			},
+			expected: Point{Row: 0, Col: 0},
+			risk:     0.2,
+		},
+		{
+			board: []string{"1F"},
+			err:   ErrNothingToGuess,
+		},
+	}
+	for i, test := range testTable {
+		board, flags := parseBoard(test.board)
+		s, err := New(board, flags, test.mines)
+		if err != nil {
+			t.Fatalf("test %d, failed: error creating solver: %v\n", i, err)
+		}
+		p, risk, err := s.Guess()
+		if err != test.err {
+			t.Fatalf("test %d, failed: expected error %v but got %v\n", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if p != test.expected || math.Abs(risk-test.risk) > 1e-9 {
+			t.Fatalf("test %d, failed: expected guess %v with risk %f but got %v with risk %f\n", i, test.expected, test.risk, p, risk)
+		}
+	}
+}
+
+// randomBoard returns a board with the mine proximity of every point and algebra.RevealedMine for the mines
+func randomBoard(rows, cols, mines int, random *rand.Rand) [][]int {
+	full := make([][]int, rows)
+	for r := range full {
+		full[r] = make([]int, cols)
+	}
+	for _, i := range random.Perm(rows * cols)[:mines] {
+		full[i/cols][i%cols] = algebra.RevealedMine
+	}
+	for r := range full {
+		for c := range full[r] {
+			if full[r][c] == algebra.RevealedMine {
+				continue
+			}
+			for sr := r - 1; sr <= r+1; sr++ {
+				for sc := c - 1; sc <= c+1; sc++ {
+					if sr >= 0 && sr < rows && sc >= 0 && sc < cols && full[sr][sc] == algebra.RevealedMine {
+						full[r][c]++
+					}
+				}
+			}
+		}
+	}
+	return full
+}
+
+func TestSolveRandomBoards(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		full := randomBoard(16, 30, 99, random)
+		board := make([][]null.Int, len(full))
+		for r := range full {
+			board[r] = make([]null.Int, len(full[r]))
+			for c := range full[r] {
+				// reveal the top half of the board
+				if full[r][c] != algebra.RevealedMine && r < len(full)/2 {
+					board[r][c] = null.IntFrom(full[r][c])
+				}
+			}
+		}
+		solution, err := Solve(board, nil, 99)
+		if err != nil {
+			t.Fatalf("test %d, failed: error solving: %v\n", i, err)
+		}
+		for _, p := range solution.Safe {
+			if full[p.Row][p.Col] == algebra.RevealedMine {
+				t.Fatalf("test %d, failed: mine %v deduced as safe\n", i, p)
+			}
+		}
+		for _, p := range solution.Mines {
+			if full[p.Row][p.Col] != algebra.RevealedMine {
+				t.Fatalf("test %d, failed: safe point %v deduced as mine\n", i, p)
+			}
+		}
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	full := randomBoard(100, 100, 2000, random)
+	board := make([][]null.Int, len(full))
+	for r := range full {
+		board[r] = make([]null.Int, len(full[r]))
+		for c := range full[r] {
+			if full[r][c] != algebra.RevealedMine && (r+c)%3 != 0 {
+				board[r][c] = null.IntFrom(full[r][c])
+			}
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := Solve(board, nil, 2000)
+		if err != nil {
+			b.Fatalf("error solving: %v\n", err)
+		}
+	}
+}
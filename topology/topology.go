@@ -0,0 +1,126 @@
+package topology
+
+// Point is a point in a board
+type Point struct {
+	Row int
+	Col int
+}
+
+// Neighbours appends to buf the neighbours of a point in a board with the given amount of rows and columns
+type Neighbours func(rows, cols, row, col int, buf []Point) []Point
+
+// offset is the distance from a point to one of its neighbours
+type offset struct {
+	row int
+	col int
+}
+
+var squareOffsets = []offset{
+	{-1, -1}, {-1, 0}, {-1, 1},
+	{0, -1}, {0, 1},
+	{1, -1}, {1, 0}, {1, 1},
+}
+
+// hex boards shift the odd rows half a point to the right
+var hexEvenRowOffsets = []offset{
+	{-1, -1}, {-1, 0},
+	{0, -1}, {0, 1},
+	{1, -1}, {1, 0},
+}
+
+var hexOddRowOffsets = []offset{
+	{-1, 0}, {-1, 1},
+	{0, -1}, {0, 1},
+	{1, 0}, {1, 1},
+}
+
+// Square is the grid where every point has 8 neighbours
+func Square(rows, cols, row, col int, buf []Point) []Point {
+	return appendNeighbours(rows, cols, row, col, squareOffsets, buf)
+}
+
+// Hex is the grid of hexagons where every point has 6 neighbours, the odd rows are shifted half a point
+// to the right
+func Hex(rows, cols, row, col int, buf []Point) []Point {
+	if row%2 == 0 {
+		return appendNeighbours(rows, cols, row, col, hexEvenRowOffsets, buf)
+	}
+	return appendNeighbours(rows, cols, row, col, hexOddRowOffsets, buf)
+}
+
+// SquareTorus is the square grid where the last row and column are next to the first row and column
+func SquareTorus(rows, cols, row, col int, buf []Point) []Point {
+	return appendWrappedNeighbours(rows, cols, row, col, squareOffsets, buf)
+}
+
+// HexTorus is the hex grid where the last row and column are next to the first row and column, the amount of
+// rows must be even so the shifted rows keep alternating across the edge
+func HexTorus(rows, cols, row, col int, buf []Point) []Point {
+	if row%2 == 0 {
+		return appendWrappedNeighbours(rows, cols, row, col, hexEvenRowOffsets, buf)
+	}
+	return appendWrappedNeighbours(rows, cols, row, col, hexOddRowOffsets, buf)
+}
+
+// Cube returns the grid of square layers where every point has 26 neighbours, 8 in its own layer and 9 in the
+// layers above and below it. The rows of every layer are stacked one after the other in the rows of the board.
+func Cube(layers int) Neighbours {
+	return func(rows, cols, row, col int, buf []Point) []Point {
+		layerRows := rows / layers
+		layer := row / layerRows
+		row = row % layerRows
+		for l := layer - 1; l <= layer+1; l++ {
+			if l < 0 || l >= layers {
+				continue
+			}
+			for _, o := range squareOffsets {
+				r := row + o.row
+				c := col + o.col
+				if r >= 0 && r < layerRows && c >= 0 && c < cols {
+					buf = append(buf, Point{Row: l*layerRows + r, Col: c})
+				}
+			}
+			if l != layer {
+				buf = append(buf, Point{Row: l*layerRows + row, Col: col})
+			}
+		}
+		return buf
+	}
+}
+
+func appendNeighbours(rows, cols, row, col int, offsets []offset, buf []Point) []Point {
+	for _, o := range offsets {
+		r := row + o.row
+		c := col + o.col
+		if r >= 0 && r < rows && c >= 0 && c < cols {
+			buf = append(buf, Point{Row: r, Col: c})
+		}
+	}
+	return buf
+}
+
+// appendWrappedNeighbours skips the point itself and the repeated points found when the board is so small that
+// wrapping reaches the same point twice
+func appendWrappedNeighbours(rows, cols, row, col int, offsets []offset, buf []Point) []Point {
+	start := len(buf)
+	for _, o := range offsets {
+		p := Point{
+			Row: ((row+o.row)%rows + rows) % rows,
+			Col: ((col+o.col)%cols + cols) % cols,
+		}
+		if p.Row == row && p.Col == col || contains(buf[start:], p) {
+			continue
+		}
+		buf = append(buf, p)
+	}
+	return buf
+}
+
+func contains(points []Point, p Point) bool {
+	for _, point := range points {
+		if point == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package topology
+
+import (
+	"reflect"
+	"testing"
+)
+
+type topologyTest struct {
+	neighbours Neighbours
+	row        int
+	col        int
+	expected   []Point
+}
+
+func TestNeighbours(t *testing.T) {
+	testTable := []topologyTest{
+		{
+			neighbours: Square,
+			row:        0,
+			col:        0,
+			expected:   []Point{{0, 1}, {1, 0}, {1, 1}},
+		},
+		{
+			neighbours: Square,
+			row:        1,
+			col:        1,
+			expected:   []Point{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}, {2, 1}, {2, 2}},
+		},
+		{
+			neighbours: Hex,
+			row:        2,
+			col:        1,
+			expected:   []Point{{1, 0}, {1, 1}, {2, 0}, {2, 2}, {3, 0}, {3, 1}},
+		},
+		{
+			neighbours: Hex,
+			row:        1,
+			col:        1,
+			expected:   []Point{{0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 1}, {2, 2}},
+		},
+		{
+			neighbours: Hex,
+			row:        0,
+			col:        0,
+			expected:   []Point{{0, 1}, {1, 0}},
+		},
+		{
+			neighbours: Hex,
+			row:        3,
+			col:        3,
+			expected:   []Point{{2, 3}, {3, 2}},
+		},
+		{
+			neighbours: SquareTorus,
+			row:        0,
+			col:        0,
+			expected:   []Point{{3, 3}, {3, 0}, {3, 1}, {0, 3}, {0, 1}, {1, 3}, {1, 0}, {1, 1}},
+		},
+		{
+			neighbours: HexTorus,
+			row:        3,
+			col:        3,
+			expected:   []Point{{2, 3}, {2, 0}, {3, 2}, {3, 0}, {0, 3}, {0, 0}},
+		},
+	}
+	for i, test := range testTable {
+		neighbours := test.neighbours(4, 4, test.row, test.col, nil)
+		if !reflect.DeepEqual(neighbours, test.expected) {
+			t.Fatalf("test %d failed: expected neighbours %v but got %v", i, test.expected, neighbours)
+		}
+	}
+}
+
+func TestSmallTorus(t *testing.T) {
+	// on a 2x2 torus every offset wraps into one of the other three points
+	neighbours := SquareTorus(2, 2, 0, 0, nil)
+	expected := []Point{{1, 1}, {1, 0}, {0, 1}}
+	if !reflect.DeepEqual(neighbours, expected) {
+		t.Fatalf("expected neighbours %v but got %v", expected, neighbours)
+	}
+}
+
+func TestCube(t *testing.T) {
+	// 3 layers of 3x3 points stacked in 9 rows
+	cube := Cube(3)
+	testTable := []struct {
+		row      int
+		col      int
+		expected int
+	}{
+		{row: 0, col: 0, expected: 7},
+		{row: 4, col: 1, expected: 26},
+		{row: 3, col: 0, expected: 11},
+		{row: 8, col: 2, expected: 7},
+	}
+	for i, test := range testTable {
+		neighbours := cube(9, 3, test.row, test.col, nil)
+		if len(neighbours) != test.expected {
+			t.Fatalf("test %d failed: expected %d neighbours but got %v", i, test.expected, neighbours)
+		}
+		for _, p := range neighbours {
+			if p.Row == test.row && p.Col == test.col {
+				t.Fatalf("test %d failed: the point is its own neighbour", i)
+			}
+			if p.Row/3-test.row/3 > 1 || test.row/3-p.Row/3 > 1 {
+				t.Fatalf("test %d failed: %v is not in a layer next to row %d", i, p, test.row)
+			}
+		}
+	}
+}